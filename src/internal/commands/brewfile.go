@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the tool registry to other formats",
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tools into the user manifest",
+}
+
+var exportBrewfileOutput string
+
+var exportBrewfileCmd = &cobra.Command{
+	Use:   "brewfile",
+	Short: "Write a Brewfile with every brew, cask and mas tool",
+	Long: `Write a Brewfile with every brew, cask and mas tool.
+
+Each line is marked with whether the tool is installed on this machine.
+
+Examples:
+  j export brewfile            Write ./Brewfile
+  j export brewfile -o -       Print the Brewfile`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results := config.CheckTools(config.Tools)
+		installed := make(map[string]bool, len(results))
+		for i, t := range config.Tools {
+			installed[t.Name] = results[i].Installed
+		}
+
+		brewfile := config.ExportBrewfile(config.Tools, installed)
+		if exportBrewfileOutput == "-" {
+			fmt.Print(brewfile)
+			return nil
+		}
+		if err := os.WriteFile(exportBrewfileOutput, []byte(brewfile), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", exportBrewfileOutput, err)
+		}
+		print.Done("Wrote " + exportBrewfileOutput)
+		return nil
+	},
+}
+
+var importBrewfileDryRun bool
+
+var importBrewfileCmd = &cobra.Command{
+	Use:   "brewfile <path>",
+	Short: "Add the brew, cask and mas lines of a Brewfile to the user manifest",
+	Long: `Add the brew, cask and mas lines of a Brewfile to the user manifest.
+
+Lines already tracked by the registry or the manifest are skipped. New
+formulae land in "Terminal & Git", casks in "GUI Apps" and App Store apps
+in "Mac App Store"; edit the manifest to recategorize them.
+
+Examples:
+  j import brewfile Brewfile             Import into the user manifest
+  j import brewfile Brewfile --dry-run   Show the entries without writing`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		entries, unsupported, err := config.ParseBrewfile(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", args[0], err)
+		}
+
+		manifest, err := config.LoadToolManifest()
+		if err != nil {
+			return err
+		}
+
+		result := config.ImportBrewfile(entries, config.Tools, manifest)
+		print.Action("🍺", "Importing "+args[0]+"...")
+		for _, e := range result.Entries {
+			print.Row(true, e.Name, e.Method+" "+e.Formula)
+		}
+		for _, reason := range result.Skipped {
+			print.Dim("  skipped " + reason)
+		}
+		for _, line := range unsupported {
+			print.Dim("  unsupported " + line)
+		}
+
+		if len(result.Entries) == 0 {
+			print.Done("Nothing to import")
+			return nil
+		}
+		if importBrewfileDryRun {
+			print.Done(fmt.Sprintf("Would add %d tools to %s", len(result.Entries), config.ToolManifestPath()))
+			return nil
+		}
+
+		manifest.Tools = append(manifest.Tools, result.Entries...)
+		if err := config.ValidateToolManifest(manifest, config.BuiltinTools(), config.Scripts); err != nil {
+			return err
+		}
+		if err := config.AppendToolManifest(config.ToolManifestPath(), result.Entries); err != nil {
+			return err
+		}
+		print.Done(fmt.Sprintf("Added %d tools to %s", len(result.Entries), config.ToolManifestPath()))
+		return nil
+	},
+}
+
+func init() {
+	exportBrewfileCmd.Flags().StringVarP(&exportBrewfileOutput, "output", "o", "Brewfile", `Brewfile path ("-" for stdout)`)
+	importBrewfileCmd.Flags().BoolVar(&importBrewfileDryRun, "dry-run", false, "Print the entries without writing the manifest")
+	exportCmd.AddCommand(exportBrewfileCmd)
+	importCmd.AddCommand(importBrewfileCmd)
+	rootCmd.AddCommand(exportCmd, importCmd)
+}
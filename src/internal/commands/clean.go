@@ -1,21 +1,28 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/jterrazz/jterrazz-cli/src/internal/config"
 	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
 	"github.com/spf13/cobra"
 )
 
-var cleanAll bool
+var (
+	cleanAll    bool
+	cleanDryRun bool
+)
 
 var cleanCmd = &cobra.Command{
-	Use:   "clean [item...]",
-	Short: "Clean system caches, Docker, Multipass, and trash",
+	Use:         "clean [item...]",
+	Annotations: loggedRunWithArgs,
+	Short:       "Clean system caches, Docker, Multipass, and trash",
 	Long: `Clean system caches and resources.
 
 Examples:
   j clean --all              Clean everything
+  j clean --all --dry-run    Show what would be cleaned
   j clean brew               Clean Homebrew cache
   j clean docker             Clean Docker resources
   j clean multipass          Clean Multipass instances
@@ -29,36 +36,45 @@ Examples:
 		}
 		return tool.FilterStrings(all, args), cobra.ShellCompDirectiveNoFileComp
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cleanDryRun {
+			return planClean(args)
+		}
+
 		if cleanAll {
 			print.Action("🧹", "Cleaning everything...")
+			var names []string
 			for _, c := range config.Cleanables {
-				runCleanable(c)
+				if c.RequiresCmd != "" && !config.CommandExists(c.RequiresCmd) {
+					print.Warning(c.RequiresCmd + " not found, skipping " + c.Name)
+					continue
+				}
+				names = append(names, c.Name)
+			}
+			if err := runEach(names, cleanItemByName); err != nil {
+				return err
 			}
 			print.Done("System cleanup completed")
-			return
+			return nil
 		}
 
 		if len(args) == 0 {
 			listCleanItems()
-			return
+			return nil
 		}
 
 		print.Action("🧹", "Cleaning selected items...")
-		for _, name := range args {
-			c := config.GetCleanableByName(name)
-			if c == nil {
-				print.Error("Unknown clean item: " + name)
-				continue
-			}
-			runCleanable(*c)
+		if err := runEach(args, cleanItemByName); err != nil {
+			return err
 		}
 		print.Done("Cleanup completed")
+		return nil
 	},
 }
 
 func init() {
 	cleanCmd.Flags().BoolVarP(&cleanAll, "all", "a", false, "Clean everything")
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "Print the clean plan without running it")
 	rootCmd.AddCommand(cleanCmd)
 }
 
@@ -78,15 +94,44 @@ func listCleanItems() {
 	)
 }
 
-func runCleanable(c config.Cleanable) {
+// planClean prints the clean plan of the given items (all items with --all)
+func planClean(names []string) error {
+	cleanables := config.Cleanables
+	if !cleanAll {
+		if len(names) == 0 {
+			listCleanItems()
+			return nil
+		}
+		cleanables = nil
+		for _, name := range names {
+			c := config.GetCleanableByName(name)
+			if c == nil {
+				return config.UnknownError("clean item", name)
+			}
+			cleanables = append(cleanables, *c)
+		}
+	}
+	printPlan("clean", config.PlanClean(cleanables))
+	return nil
+}
+
+func cleanItemByName(name string) error {
+	c := config.GetCleanableByName(name)
+	if c == nil {
+		return config.UnknownError("clean item", name)
+	}
+	return runCleanable(*c)
+}
+
+func runCleanable(c config.Cleanable) error {
 	if c.RequiresCmd != "" && !config.CommandExists(c.RequiresCmd) {
-		print.Warning(c.RequiresCmd + " not found, skipping")
-		return
+		return config.MissingDependencyError(c.RequiresCmd + " not found, cannot clean " + c.Name)
 	}
 
 	print.Action("🧹", c.Description+"...")
-	if c.CleanFn != nil {
-		c.CleanFn()
+	if err := c.Clean(); err != nil {
+		return fmt.Errorf("failed to clean %s: %w", c.Name, err)
 	}
 	print.Row(true, c.Name, "completed")
+	return nil
 }
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose configuration problems",
+	Long: `Diagnose configuration problems.
+
+Examples:
+  j doctor                   Run every check
+  j doctor registry          Validate the tool and script registries
+  j doctor path              Find shadowed and duplicated tool binaries on PATH`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return errors.Join(doctorRegistry(), doctorPath())
+	},
+}
+
+var doctorRegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Validate the tool and script registries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doctorRegistry()
+	},
+}
+
+var doctorPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Find shadowed and duplicated tool binaries on PATH",
+	Long: `Find shadowed and duplicated tool binaries on PATH.
+
+Reports every tracked command found more than once on PATH, and commands
+resolving outside the place their install method puts them (the brew
+prefix, ~/.nvm, ~/.bun/bin), with a suggested fix.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doctorPath()
+	},
+}
+
+func init() {
+	doctorCmd.AddCommand(doctorRegistryCmd)
+	doctorCmd.AddCommand(doctorPathCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorRegistry validates the registries (including user manifest tools)
+func doctorRegistry() error {
+	print.Action("🩺", "Checking registry...")
+
+	err := config.ValidateRegistry(config.Tools, config.Scripts)
+	if err == nil {
+		print.Row(true, "registry", fmt.Sprintf("%d tools, %d scripts", len(config.Tools), len(config.Scripts)))
+		return nil
+	}
+
+	problems := strings.Split(err.Error(), "\n")
+	for _, problem := range problems {
+		print.Row(false, problem, "")
+	}
+	return errors.New(pluralize(len(problems), "registry problem"))
+}
+
+// doctorPath checks where the registry commands resolve on PATH
+func doctorPath() error {
+	print.Empty()
+	print.Action("🩺", "Checking PATH...")
+
+	pathEnv := os.Getenv("PATH")
+	for _, dir := range tool.PathDuplicates(pathEnv) {
+		print.Dim("  " + dir + " is listed more than once in PATH")
+	}
+
+	problems := config.CheckPath(config.Tools, pathEnv)
+	if len(problems) == 0 {
+		print.Row(true, "path", "every tool resolves to its install method")
+		return nil
+	}
+
+	for _, problem := range problems {
+		print.Row(false, problem.Tool, problem.Problem)
+		print.Dim("    " + problem.Fix)
+	}
+	return errors.New(pluralize(len(problems), "PATH problem"))
+}
+
+// pluralize returns "1 problem" or "3 problems"
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+)
+
+// Exit codes of j, so scripts can tell failures apart
+const (
+	ExitOK                = 0
+	ExitError             = 1 // Any other failure
+	ExitPartialFailure    = 2 // Some items of a batch failed, others succeeded
+	ExitUnknownItem       = 3 // A tool, package, script or item name matched nothing
+	ExitMissingDependency = 4 // A required command or tool is not installed
+	ExitAborted           = 5 // The user declined a confirmation
+)
+
+// ExitCode maps a command error to its exit code
+func ExitCode(err error) int {
+	var batch *config.BatchError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &batch) && batch.Partial():
+		return ExitPartialFailure
+	case errors.Is(err, config.ErrAborted):
+		return ExitAborted
+	case errors.Is(err, config.ErrUnknownItem):
+		return ExitUnknownItem
+	case errors.Is(err, config.ErrMissingDependency):
+		return ExitMissingDependency
+	}
+	return ExitError
+}
+
+// runEach runs fn for every name without stopping at failures. Failures of a
+// batch are printed as they happen and summarized in the returned error; a
+// single name's error is returned as is.
+func runEach(names []string, fn func(name string) error) error {
+	var errs []error
+	for _, name := range names {
+		if err := fn(name); err != nil {
+			if len(names) > 1 {
+				print.Error(err.Error())
+			}
+			errs = append(errs, err)
+		}
+	}
+	return config.NewBatchError(len(names), errs)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+)
+
+func TestExitCode(t *testing.T) {
+	failed := errors.New("exit status 1")
+	unknown := config.UnknownError("tool", "foo")
+	tests := []struct {
+		name     string
+		given    error
+		expected int
+	}{
+		{"success", nil, ExitOK},
+		{"plain error", failed, ExitError},
+		{"unknown item", unknown, ExitUnknownItem},
+		{"wrapped unknown item", fmt.Errorf("%w. Run: j logs", unknown), ExitUnknownItem},
+		{"missing dependency", config.MissingDependencyError("copier not installed"), ExitMissingDependency},
+		{"aborted", config.AbortedError("homebrew installer script not approved"), ExitAborted},
+		{"some items failed", config.NewBatchError(3, []error{unknown}), ExitPartialFailure},
+		{"every item unknown", config.NewBatchError(2, []error{unknown, unknown}), ExitUnknownItem},
+		{"every item failed", config.NewBatchError(2, []error{failed, failed}), ExitError},
+		{"single item", config.NewBatchError(1, []error{unknown}), ExitUnknownItem},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.given); got != tt.expected {
+				t.Errorf("ExitCode(%v) = %d, expected %d", tt.given, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/components"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+	"github.com/spf13/cobra"
+)
+
+var infoJSON bool
+
+var infoCmd = &cobra.Command{
+	Use:   "info <tool>",
+	Short: "Show everything j knows about a tool",
+	Long: `Show everything j knows about a tool: category, install method, formula,
+binary path, version, dependency tree, reverse dependencies, setup scripts,
+upgrade route and the registry entry defining it.
+
+Examples:
+  j info go           Show the go tool
+  j info java         Aliases work too (openjdk)
+  j info node --json  Print the same details as JSON`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var all []string
+		for _, t := range config.Tools {
+			all = append(append(all, t.Name), t.Aliases...)
+		}
+		return all, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := config.GetToolInfo(args[0])
+		if err != nil {
+			return err
+		}
+		if infoJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(info)
+		}
+		printToolInfo(info)
+		return nil
+	},
+}
+
+func init() {
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "Print the details as JSON")
+	rootCmd.AddCommand(infoCmd)
+}
+
+func printToolInfo(info config.ToolInfo) {
+	print.Title(info.Name)
+	if info.Description != "" {
+		print.Dim(info.Description)
+	}
+	print.Empty()
+
+	field := func(label, value string) {
+		if value != "" {
+			print.Linef(components.PageIndent+"%s %s", print.RenderMuted(fmt.Sprintf("%-12s", label)), value)
+		}
+	}
+	field("Category", info.Category)
+	field("Method", info.Method)
+	field("Formula", info.Formula)
+	if len(info.Aliases) > 0 {
+		field("Aliases", fmt.Sprint(info.Aliases))
+	}
+	field("Binary", info.Binary)
+	field("App", info.App)
+	version := "not installed"
+	if info.Installed {
+		version = "installed"
+		if info.Version != "" {
+			version = info.Version
+		}
+	}
+	field("Version", print.RenderStatusIcon(info.Installed)+" "+version)
+	field("Requires", info.MinVersion)
+	source := info.Source.Registry
+	if info.Source.Manifest != "" {
+		source += " (" + info.Source.Manifest + ")"
+	}
+	field("Source", source)
+
+	print.Empty()
+	print.Category("Dependencies")
+	if len(info.Dependencies) == 0 {
+		print.Dim(components.PageIndent + "none")
+	}
+	printDependencyTree(info.Dependencies, components.PageIndent)
+	print.Empty()
+
+	print.Category("Used by")
+	if len(info.Dependents) == 0 {
+		print.Dim(components.PageIndent + "none")
+	}
+	for _, dep := range info.Dependents {
+		print.Row(dep.Installed, dep.Name, "")
+	}
+	print.Empty()
+
+	if len(info.Scripts) > 0 {
+		print.Category("Scripts")
+		for _, s := range info.Scripts {
+			print.Row(s.State == "configured", s.Name, s.State)
+		}
+		print.Empty()
+	}
+
+	print.Category("Upgrade")
+	if info.Upgrade.Command == "" {
+		print.Dim(components.PageIndent + info.Upgrade.Note)
+		return
+	}
+	print.Linef(components.PageIndent+"%s", print.RenderSpecial("$ "+info.Upgrade.Command))
+	if info.Upgrade.Flag != "" {
+		print.Dim(components.PageIndent + "also upgraded by j upgrade " + info.Upgrade.Flag)
+	}
+}
+
+// printDependencyTree prints dependencies as a tree, each under its dependent
+func printDependencyTree(nodes []config.DependencyNode, prefix string) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		print.Linef("%s%s%s %s", prefix, print.RenderMuted(branch), print.RenderStatusIcon(node.Installed), node.Name)
+		printDependencyTree(node.Dependencies, prefix+print.RenderMuted(indent))
+	}
+}
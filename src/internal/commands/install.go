@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/jterrazz/jterrazz-cli/src/internal/config"
 	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
@@ -10,45 +14,414 @@ import (
 )
 
 var installCmd = &cobra.Command{
-	Use:   "install [tool...]",
-	Short: "Install development tools",
+	Use:         "install [tool...]",
+	Annotations: loggedRunWithArgs,
+	Short:       "Install development tools",
 	Long: `Install development tools.
 
 Examples:
   j install homebrew        Install Homebrew
   j install nvm             Install NVM
   j install go python node  Install specific tools
+  j install --all           Install every tool, in parallel
+  j install --all --jobs 8  Install with up to 8 concurrent installers
+  j install --profile backend
+                            Install the tools of a profile and their dependencies
+  j install --dry-run go    Show what would be installed
+  j install --graph | dot -Tsvg > tools.svg
+                            Draw the dependency graph of every tool
+  j install --graph --format mermaid --installed node
+                            Mermaid graph of node and its dependencies, colored by installed state
+  j install --frozen        Install the versions pinned in j.lock.json
+  j install --show-script homebrew
+                            Print an installer script without running it
   j install                 List available tools`,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		var all []string
 		for _, t := range config.Tools {
-			all = append(all, t.Name)
+			if !t.Unsupported {
+				all = append(append(all, t.Name), t.Aliases...)
+			}
 		}
 		return tool.FilterStrings(all, args), cobra.ShellCompDirectiveNoFileComp
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, flag := range []string{"format", "installed"} {
+			if cmd.Flags().Changed(flag) && !installGraph {
+				return fmt.Errorf("--%s only applies to --graph", flag)
+			}
+		}
+
+		if installProfile != "" {
+			profile, err := config.FindProfile(installProfile)
+			if err != nil {
+				return err
+			}
+			args = append(args, profile.ToolNames()...)
+			if len(args) == 0 {
+				print.Info("Profile " + profile.Name + " has no tools for this platform")
+				return nil
+			}
+		}
+
+		if installGraph {
+			return printInstallGraph(args)
+		}
+
+		if installDryRun {
+			return planInstall(args)
+		}
+
+		if installShowScript {
+			return showInstallScripts(args)
+		}
+
+		if installFrozen {
+			return installFromLockfile(args)
+		}
+
+		if installAll || installProfile != "" || cmd.Flags().Changed("jobs") {
+			return installInParallel(args)
+		}
+
 		if len(args) == 0 {
 			listAvailableTools()
-			return
+			return nil
 		}
 
 		print.Action("📦", "Installing selected tools...")
-		for _, name := range args {
-			installToolByName(name)
+		if err := runEach(args, installToolByName); err != nil {
+			return err
 		}
 		print.Done("Done")
+		return nil
 	},
 }
 
+var (
+	installDryRun bool
+	installAll    bool
+	installJobs   int
+	installFrozen bool
+	installLock   string
+
+	installShowScript bool
+	installRefresh    bool
+	installProfile    string
+
+	installGraph          bool
+	installGraphFormat    string
+	installGraphInstalled bool
+)
+
 func init() {
+	installCmd.Flags().BoolVarP(&installAll, "all", "a", false, "Install every tool and its dependencies")
+	installCmd.Flags().IntVarP(&installJobs, "jobs", "j", 4, "Maximum concurrent installers")
+	installCmd.Flags().BoolVar(&installFrozen, "frozen", false, "Install the versions recorded by j lock")
+	installCmd.Flags().StringVar(&installLock, "lockfile", config.LockfileName, "Lockfile used by --frozen")
+	installCmd.Flags().BoolVar(&installShowScript, "show-script", false, "Print the installer script of curl | bash tools without running it")
+	installCmd.Flags().BoolVar(&installRefresh, "refresh", false, "Ignore cached tool checks when listing tools")
+	installCmd.Flags().BoolVar(&installDryRun, "dry-run", false, "Print the install plan without running it (all tools when none given)")
+	installCmd.Flags().StringVar(&installProfile, "profile", "", "Install the tools of a profile (minimal, backend, mobile, ai, media or one from jrc.json)")
+	installCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+	installCmd.Flags().BoolVar(&installGraph, "graph", false, "Print the dependency graph of the tools and their scripts (all tools when none given)")
+	installCmd.Flags().StringVar(&installGraphFormat, "format", "dot", "Graph format: dot or mermaid")
+	installCmd.Flags().BoolVar(&installGraphInstalled, "installed", false, "Color the graph by installed state")
+	installCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.GraphFormats, cobra.ShellCompDirectiveNoFileComp
+	})
+	installCmd.MarkFlagsMutuallyExclusive("all", "profile")
 	rootCmd.AddCommand(installCmd)
 }
 
+// installInParallel installs the given tools and their missing dependencies
+// (every tool with --all) concurrently, then prints a per-tool summary
+func installInParallel(names []string) error {
+	tools := config.GetToolsInDependencyOrder()
+	if !installAll {
+		for i, name := range names {
+			t, err := config.FindTool(name)
+			if err != nil {
+				return err
+			}
+			names[i] = t.Name
+		}
+		tools = config.GetDependencyOrder(names)
+	}
+
+	print.Action("📦", fmt.Sprintf("Installing %d tools (%d jobs)...", len(tools), installJobs))
+	results := config.InstallTools(tools, config.InstallOptions{
+		Jobs:    installJobs,
+		OnStart: func(t config.Tool) { print.Installing(t.Name) },
+		OnDone: func(r config.InstallResult) {
+			if r.Status == config.InstallSucceeded || r.Status == config.InstallFailed {
+				print.Row(r.Status == config.InstallSucceeded, r.Tool.Name, r.Status.String()+" in "+r.Duration.Round(time.Second).String())
+			}
+		},
+	})
+
+	// Post-install scripts may prompt, so they run once everything is settled
+	var errs []error
+	for _, r := range results {
+		if r.Status == config.InstallSucceeded {
+			if err := runToolScripts(r.Tool); err != nil {
+				print.Error(err.Error())
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	printInstallSummary(results)
+
+	for _, r := range results {
+		if r.Status == config.InstallFailed || r.Status == config.InstallSkipped {
+			errs = append(errs, fmt.Errorf("%s %s: %w", r.Tool.Name, r.Status, r.Err))
+		}
+	}
+	return config.NewBatchError(len(results), errs)
+}
+
+// runToolScripts runs the post-install scripts of a tool
+func runToolScripts(t config.Tool) error {
+	var errs []error
+	for _, scriptName := range t.Scripts {
+		if err := runSetupItem(scriptName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// printInstallSummary prints one row per tool, then the failures with their output tail
+func printInstallSummary(results []config.InstallResult) {
+	print.Empty()
+	print.Info("Summary:")
+
+	counts := make(map[config.InstallStatus]int)
+	for _, r := range results {
+		counts[r.Status]++
+		detail := r.Status.String()
+		if r.Err != nil {
+			detail += ": " + r.Err.Error()
+		}
+		ok := r.Status == config.InstallSucceeded || r.Status == config.InstallAlreadyInstalled
+		print.Row(ok, r.Tool.Name, detail)
+	}
+
+	for _, r := range results {
+		if r.Status != config.InstallFailed || r.Output == "" {
+			continue
+		}
+		print.Empty()
+		print.Category(r.Tool.Name + " output:")
+		print.Dim(lastLines(r.Output, 10))
+	}
+
+	print.Empty()
+	summary := fmt.Sprintf("%d installed, %d already installed, %d failed, %d skipped",
+		counts[config.InstallSucceeded], counts[config.InstallAlreadyInstalled],
+		counts[config.InstallFailed], counts[config.InstallSkipped])
+	if counts[config.InstallFailed]+counts[config.InstallSkipped] > 0 {
+		print.Warning(summary)
+		return
+	}
+	print.Done(summary)
+}
+
+// lastLines returns the last n lines of s
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// installFromLockfile installs the locked tools (or the named subset) at their
+// locked versions, warning where the backend cannot pin them
+func installFromLockfile(names []string) error {
+	lock, err := config.ReadLockfile(installLock)
+	if err != nil {
+		return err
+	}
+
+	p := config.CurrentPlatform()
+	if platform := p.OS + "/" + p.Arch; lock.Platform != platform {
+		print.Warning(installLock + " was generated on " + lock.Platform + ", this machine is " + platform)
+	}
+
+	locked := make(map[string]config.LockedTool, len(lock.Tools))
+	var lockedNames []string
+	for _, entry := range lock.Tools {
+		if config.GetToolByName(entry.Name) == nil {
+			print.Warning("Unknown tool in " + installLock + ": " + entry.Name)
+			continue
+		}
+		locked[entry.Name] = entry
+		lockedNames = append(lockedNames, entry.Name)
+	}
+
+	if len(names) > 0 {
+		lockedNames = nil
+		for _, name := range names {
+			if t, err := config.FindTool(name); err == nil {
+				name = t.Name
+			}
+			if _, ok := locked[name]; !ok {
+				return config.UnknownError("tool in "+installLock, name)
+			}
+			lockedNames = append(lockedNames, name)
+		}
+	}
+
+	print.Action("📦", "Installing locked versions...")
+	var ordered []string
+	for _, t := range config.GetDependencyOrder(lockedNames) {
+		if _, ok := locked[t.Name]; ok { // Skip dependencies outside the lockfile
+			ordered = append(ordered, t.Name)
+		}
+	}
+	err = runEach(ordered, func(name string) error {
+		return installLockedTool(*config.GetToolByName(name), locked[name].Version)
+	})
+	if err != nil {
+		return err
+	}
+	print.Done("Done")
+	return nil
+}
+
+func installLockedTool(t config.Tool, version string) error {
+	if result := t.Check(); result.Installed {
+		if version != "" && result.Version != "" && result.Version != version {
+			print.Warning(fmt.Sprintf("%s %s is installed, %s is locked. Run: j uninstall %s", t.Name, result.Version, version, t.Name))
+			return nil
+		}
+		print.Row(true, t.Name, strings.TrimSpace("already installed "+result.Version))
+		return nil
+	}
+
+	print.Installing(t.Name + " " + version)
+	warning, err := t.InstallPinned(version)
+	if warning != "" {
+		print.Warning(t.Name + ": " + warning)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install %s: %w", t.Name, err)
+	}
+	print.Row(true, t.Name, strings.TrimSpace("installed "+version))
+	return runToolScripts(t)
+}
+
+// showInstallScripts prints the installer scripts of the given tools with their checksum status
+func showInstallScripts(names []string) error {
+	if len(names) == 0 {
+		print.Usage("Usage: j install --show-script <tool> [tool...]")
+		return nil
+	}
+
+	return runEach(names, func(name string) error {
+		t, err := config.FindTool(name)
+		if err != nil {
+			return err
+		}
+		review, cleanup, err := t.ReviewInstallScript()
+		defer cleanup()
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(review.Content))
+		print.Empty()
+		printScriptChecksum(review)
+		return nil
+	})
+}
+
+// printScriptChecksum prints where the script comes from and whether it matches its pin
+func printScriptChecksum(review config.ScriptReview) {
+	print.Info(review.Tool + " installer: " + review.URL)
+	print.Dim(fmt.Sprintf("  %d bytes, %d lines, sha256 %s", len(review.Content), strings.Count(string(review.Content), "\n"), review.SHA256))
+	switch {
+	case review.Verified():
+		print.Row(true, "checksum", "matches the pinned SHA-256")
+	case review.Pinned == "":
+		print.Warning("No pinned SHA-256 for " + review.Tool + ", review the script before running it")
+	default:
+		print.Warning("Checksum mismatch: pinned " + review.Pinned)
+	}
+}
+
+// approveScript shows what changed in an unverified installer script and asks to run it
+func approveScript(review config.ScriptReview) bool {
+	printScriptChecksum(review)
+	switch {
+	case review.Diff != "":
+		print.Info("Changes since the last approved copy:")
+		print.Dim(review.Diff)
+	case review.Cached:
+		print.Dim("  Unchanged since the last approved copy")
+	default:
+		print.Dim("  Review it with: j install --show-script " + review.Tool)
+	}
+	return confirm("Run the " + review.Tool + " installer script?")
+}
+
+// planInstall prints the install plan of the given tools (all installable tools when empty)
+func planInstall(names []string) error {
+	for i, name := range names {
+		t, err := config.FindTool(name)
+		if err != nil {
+			return err
+		}
+		names[i] = t.Name
+	}
+
+	steps, err := config.PlanInstall(names)
+	if err != nil {
+		return err
+	}
+	printPlan("install", steps)
+	return nil
+}
+
+// printInstallGraph prints the dependency graph of the given tools (every
+// installable tool when none given) to stdout, so it can be piped to dot
+func printInstallGraph(names []string) error {
+	tools := config.GetToolsInDependencyOrder()
+	if len(names) > 0 {
+		for i, name := range names {
+			t, err := config.FindTool(name)
+			if err != nil {
+				return err
+			}
+			names[i] = t.Name
+		}
+		tools = config.GetDependencyOrder(names)
+	}
+
+	var installed map[string]bool
+	if installGraphInstalled {
+		installed = make(map[string]bool, len(tools))
+		for i, result := range config.CheckTools(tools) {
+			installed[tools[i].Name] = result.Installed
+		}
+	}
+
+	graph, err := config.RenderDependencyGraph(tools, installGraphFormat, installed)
+	if err != nil {
+		return err
+	}
+	fmt.Print(graph)
+	return nil
+}
+
 func listAvailableTools() {
 	print.Info("Available tools:")
 	print.Empty()
 
-	// Check all tools in parallel
+	// Check all tools in parallel, from the cache when still valid
+	cache := openCheckCache(installRefresh)
+	defer cache.Save()
 	results := make(map[string]config.CheckResult, len(config.Tools))
 	var mu sync.Mutex
 	var wg sync.WaitGroup
@@ -57,7 +430,7 @@ func listAvailableTools() {
 		wg.Add(1)
 		go func(t *config.Tool) {
 			defer wg.Done()
-			result := t.Check()
+			result := cache.Check(*t)
 			mu.Lock()
 			results[t.Name] = result
 			mu.Unlock()
@@ -65,10 +438,18 @@ func listAvailableTools() {
 	}
 	wg.Wait()
 
+	unsupported := 0
 	knownCategories := make(map[config.ToolCategory]bool, len(config.ToolCategories))
 	for _, category := range config.ToolCategories {
 		knownCategories[category] = true
-		tools := config.GetToolsByCategory(category)
+		var tools []config.Tool
+		for _, t := range config.GetToolsByCategory(category) {
+			if t.Unsupported {
+				unsupported++
+				continue
+			}
+			tools = append(tools, t)
+		}
 		if len(tools) == 0 {
 			continue
 		}
@@ -82,7 +463,7 @@ func listAvailableTools() {
 	// Fallback: show any tools using categories not listed in ToolCategories.
 	currentCategory := config.ToolCategory("")
 	for _, t := range config.Tools {
-		if knownCategories[t.Category] {
+		if knownCategories[t.Category] || t.Unsupported {
 			continue
 		}
 		if t.Category != currentCategory {
@@ -93,25 +474,25 @@ func listAvailableTools() {
 	}
 
 	print.Empty()
+	if unsupported > 0 {
+		print.Dim(fmt.Sprintf("%d tools not available on %s are hidden", unsupported, config.CurrentPlatform().OS))
+	}
 	print.Usage("Usage: j install <tool> [tool...]")
 }
 
-func installToolByName(name string) {
-	// Handle "brew" as alias for "homebrew"
-	if name == "brew" {
-		name = "homebrew"
+func installToolByName(name string) error {
+	t, err := config.FindTool(name)
+	if err != nil {
+		return err
 	}
-
-	t := config.GetToolByName(name)
-	if t == nil {
-		print.Error("Unknown tool: " + name)
-		return
+	if t.Unsupported {
+		return fmt.Errorf("%s is not available on %s", t.Name, config.CurrentPlatform().OS)
 	}
 
 	result := t.Check()
 	if result.Installed {
 		print.Row(true, t.Name, "already installed")
-		return
+		return nil
 	}
 
 	// Check dependencies
@@ -122,19 +503,15 @@ func installToolByName(name string) {
 		}
 		depResult := depTool.Check()
 		if !depResult.Installed {
-			print.Error(depName + " required for " + t.Name + ". Run: j install " + depName)
-			return
+			return config.MissingDependencyError(depName + " required for " + t.Name + ". Run: j install " + depName)
 		}
 	}
 
 	print.Installing(t.Name)
 	if err := t.Install(); err != nil {
-		print.Error("Failed to install " + t.Name + ": " + err.Error())
-	} else {
-		print.Row(true, t.Name, "installed")
-		// Run post-install scripts
-		for _, scriptName := range t.Scripts {
-			runSetupItem(scriptName)
-		}
+		return fmt.Errorf("failed to install %s: %w", t.Name, err)
 	}
+	print.Row(true, t.Name, "installed")
+	// Run post-install scripts
+	return runToolScripts(*t)
 }
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInstallGraphFlagsNeedGraph(t *testing.T) {
+	for _, flag := range []string{"format", "installed"} {
+		t.Run(flag, func(t *testing.T) {
+			value := map[string]string{"format": "mermaid", "installed": "true"}[flag]
+			if err := installCmd.Flags().Set(flag, value); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				installCmd.Flags().Lookup(flag).Changed = false
+				installGraphFormat, installGraphInstalled = "dot", false
+			})
+
+			err := installCmd.RunE(installCmd, []string{"go"})
+			if err == nil || !strings.Contains(err.Error(), "--"+flag+" only applies to --graph") {
+				t.Errorf("RunE() error = %v, want --%s rejected without --graph", err, flag)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+	"github.com/spf13/cobra"
+)
+
+var lockOutput string
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Snapshot installed tool versions to j.lock.json",
+	Long: `Snapshot installed tool versions to j.lock.json.
+
+The lockfile records each installed tool with its version, install method
+and formula. Commit it and run j install --frozen on other machines.
+
+Examples:
+  j lock                     Write ./j.lock.json
+  j lock -o team.lock.json   Write to another path`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		print.Action("🔒", "Locking installed tool versions...")
+
+		lock := config.BuildLockfile(config.Tools)
+		for _, entry := range lock.Tools {
+			print.Row(true, entry.Name, entry.Version)
+		}
+
+		if err := config.WriteLockfile(lockOutput, lock); err != nil {
+			return err
+		}
+		print.Done(fmt.Sprintf("Wrote %s (%d tools)", lockOutput, len(lock.Tools)))
+		return nil
+	},
+}
+
+func init() {
+	lockCmd.Flags().StringVarP(&lockOutput, "output", "o", config.LockfileName, "Lockfile path")
+	rootCmd.AddCommand(lockCmd)
+}
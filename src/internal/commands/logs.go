@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+	"github.com/spf13/cobra"
+)
+
+var logsLimit int
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [id]",
+	Short: "Show the logs of install, upgrade, clean, sync and setup runs",
+	Long: `Show the logs of install, upgrade, clean, sync and setup runs.
+
+Every subprocess of these commands is logged under ~/.local/state/jterrazz/logs.
+The 50 most recent runs of the last 30 days are kept.
+
+Examples:
+  j logs                     List recent runs
+  j logs 20250314-093012     Show one run
+  j logs last                Show the most recent run`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		runs, _ := config.ReadRunLogs()
+		ids := []string{"last"}
+		for _, run := range runs {
+			ids = append(ids, run.ID)
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			return showRunLog(args[0])
+		}
+		return listRunLogs()
+	},
+}
+
+func init() {
+	logsCmd.Flags().IntVarP(&logsLimit, "limit", "n", 20, "Number of runs to list")
+	rootCmd.AddCommand(logsCmd)
+}
+
+func listRunLogs() error {
+	runs, err := config.ReadRunLogs()
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		print.Info("No runs logged yet")
+		return nil
+	}
+
+	print.Info("Recent runs:")
+	print.Empty()
+	start := max(len(runs)-logsLimit, 0)
+	for i := len(runs) - 1; i >= start; i-- {
+		run := runs[i]
+		detail := fmt.Sprintf("%-28s %s", run.Command, print.RenderMuted(describeRun(run)))
+		print.Row(run.Status != config.RunFailed, run.ID, detail)
+	}
+	print.Empty()
+	print.Usage("Usage: j logs <id>")
+	return nil
+}
+
+func showRunLog(id string) error {
+	run, err := config.FindRunLog(id)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(config.RunLogPath(run.ID))
+	if err != nil {
+		return err
+	}
+
+	print.Row(run.Status != config.RunFailed, run.ID, run.Command)
+	print.Dim("  " + describeRun(run) + " • " + config.RunLogPath(run.ID))
+	print.Empty()
+	fmt.Print(string(data))
+	return nil
+}
+
+// describeRun summarizes a run, e.g. "ok • 3 commands • 12s • Mar 14 09:30"
+func describeRun(run config.RunLog) string {
+	summary := fmt.Sprintf("%s • %s", run.Status, pluralize(run.Commands, "command"))
+	if run.Failed > 0 {
+		summary += fmt.Sprintf(" (%d failed)", run.Failed)
+	}
+	if run.Status != config.RunRunning {
+		summary += " • " + run.Duration.Round(time.Second).String()
+	}
+	return summary + " • " + run.Started.Format("Jan 2 15:04")
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/components"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+)
+
+// printPlan prints a --dry-run plan. Nothing is executed.
+func printPlan(title string, steps []config.PlanStep) {
+	print.Action("📋", "Dry run: "+title+" (nothing will be executed)")
+	print.Empty()
+
+	run := 0
+	for _, step := range steps {
+		if step.Skip != "" {
+			print.Linef(components.PageIndent+"%s %-18s %-7s %s", print.RenderMuted("-  "), step.Name, step.Method, print.RenderMuted(step.Skip))
+			continue
+		}
+
+		run++
+		print.Linef(components.PageIndent+"%-3s %-18s %-7s %s", fmt.Sprintf("%d.", run), step.Name, step.Method, print.RenderMuted(step.Note))
+		for _, line := range step.Commands {
+			print.Linef(components.PageIndent+"    %s", print.RenderSpecial("$ "+line))
+		}
+	}
+
+	print.Empty()
+	print.Dim(fmt.Sprintf("%d steps would run, %d skipped", run, len(steps)-run))
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+)
+
+// stdin is shared by every prompt: a reader per prompt would buffer ahead and
+// swallow the answers piped for the following ones
+var stdin = bufio.NewReader(os.Stdin)
+
+func init() {
+	config.ApproveScript = approveScript
+}
+
+// confirm asks a yes/no question on the terminal. Anything but y/yes is a no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := stdin.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestConfirmReadsPipedAnswersInOrder(t *testing.T) {
+	original := stdin
+	t.Cleanup(func() { stdin = original })
+	stdin = bufio.NewReader(strings.NewReader("y\nn\nyes\n"))
+
+	expected := []bool{true, false, true, false}
+	for i, want := range expected {
+		if got := confirm("Continue?"); got != want {
+			t.Errorf("confirm #%d = %v, expected %v", i+1, got, want)
+		}
+	}
+}
@@ -13,34 +13,32 @@ import (
 var remoteCmd = &cobra.Command{
 	Use:   "remote",
 	Short: "Manage remote access connectivity",
-	Run: func(cmd *cobra.Command, args []string) {
-		runRemoteStatus()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRemoteStatus()
 	},
 }
 
 var remoteSetupCmd = &cobra.Command{
 	Use:   "setup",
 	Short: "Interactive remote access setup",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		setupview.InitRemoteState()
-		components.RunOrExit(setupview.RemoteConfig())
+		return components.Run(setupview.RemoteConfig())
 	},
 }
 
 var remoteUpCmd = &cobra.Command{
 	Use:   "up",
 	Short: "Connect remote access",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		settings, err := config.LoadRemoteSettings()
 		if err != nil {
-			print.Error(err.Error())
-			return
+			return err
 		}
 
 		mode, err := config.RemoteUp(settings)
 		if err != nil {
-			print.Error(err.Error())
-			return
+			return err
 		}
 
 		print.Success(fmt.Sprintf("Remote access connected (%s mode)", mode))
@@ -49,34 +47,34 @@ var remoteUpCmd = &cobra.Command{
 				print.Warning("Connected, but keep-awake is not active")
 			}
 		}
+		return nil
 	},
 }
 
 var remoteDownCmd = &cobra.Command{
 	Use:   "down",
 	Short: "Disconnect remote access",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		settings, err := config.LoadRemoteSettings()
 		if err != nil {
-			print.Error(err.Error())
-			return
+			return err
 		}
 
 		mode, err := config.RemoteDown(settings)
 		if err != nil {
-			print.Error(err.Error())
-			return
+			return err
 		}
 
 		print.Success(fmt.Sprintf("Remote access disconnected (%s mode)", mode))
+		return nil
 	},
 }
 
 var remoteStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show remote access status",
-	Run: func(cmd *cobra.Command, args []string) {
-		runRemoteStatus()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRemoteStatus()
 	},
 }
 
@@ -88,11 +86,10 @@ func init() {
 	rootCmd.AddCommand(remoteCmd)
 }
 
-func runRemoteStatus() {
+func runRemoteStatus() error {
 	settings, err := config.LoadRemoteSettings()
 	if err != nil {
-		print.Error(err.Error())
-		return
+		return err
 	}
 
 	status, err := config.RemoteStatusInfo(settings)
@@ -104,7 +101,7 @@ func runRemoteStatus() {
 		if settings.Hostname != "" {
 			print.Linef("Hostname: %s", settings.Hostname)
 		}
-		return
+		return nil
 	}
 
 	print.Linef("Mode: %s", status.Mode)
@@ -119,4 +116,5 @@ func runRemoteStatus() {
 	if status.Mode == config.RemoteModeUserspace {
 		print.Linef("Keep awake: %t", status.KeepAwake)
 	}
+	return nil
 }
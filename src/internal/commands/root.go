@@ -1,6 +1,11 @@
 package commands
 
 import (
+	"os"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
 	"github.com/spf13/cobra"
 )
 
@@ -8,13 +13,79 @@ var rootCmd = &cobra.Command{
 	Use:   "j",
 	Short: "jterrazz unified command system",
 	Long:  "A unified CLI tool for development workflow automation.",
+
+	PersistentPreRun: startRunLog,
+
+	// Execute prints errors itself and maps them to exit codes
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
+// loggedRun annotates commands whose installs, upgrades and removals are kept
+// in a run log (see j logs)
+var loggedRun = map[string]string{"log-run": "true"}
+
+// loggedRunWithArgs annotates logged commands that only list what they can act
+// on when run without arguments or flags
+var loggedRunWithArgs = map[string]string{"log-run": "with-args"}
+
+// activeRun is the run log of the current command, if it is logged
+var activeRun *config.ActiveRunLog
+
 func init() {
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	cobra.OnInitialize(loadUserManifest, config.ApplyPlatform)
 }
 
+// Execute runs the command line. On failure it prints the error and exits
+// with its ExitCode.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if activeRun != nil {
+		if finishErr := activeRun.Finish(err); finishErr != nil {
+			print.Warning("Failed to save the run log: " + finishErr.Error())
+		}
+	}
+	if err != nil {
+		print.Error(err.Error())
+		os.Exit(ExitCode(err))
+	}
+	return nil
+}
+
+// startRunLog starts a run log when the command is annotated with loggedRun,
+// unless the invocation is read-only (plans, checks, listings)
+func startRunLog(cmd *cobra.Command, args []string) {
+	switch cmd.Annotations["log-run"] {
+	case "true":
+	case "with-args":
+		if len(args) == 0 && cmd.Flags().NFlag() == 0 {
+			return
+		}
+	default:
+		return
+	}
+	for _, flag := range []string{"dry-run", "graph", "check", "show-script"} {
+		if set, err := cmd.Flags().GetBool(flag); err == nil && set {
+			return
+		}
+	}
+
+	run, err := config.StartRunLog(strings.Join(append([]string{cmd.CommandPath()}, args...), " "))
+	if err != nil {
+		print.Warning("Run log disabled: " + err.Error())
+		return
+	}
+	activeRun = run
+}
+
+// loadUserManifest merges ~/.config/jterrazz/tools.yaml into the tool registry.
+// Runs before every command (including shell completion) so all commands see user tools;
+// warnings go to stderr so they never end up in completion output.
+func loadUserManifest() {
+	if err := config.ApplyUserManifest(); err != nil {
+		print.WarningStderr("Ignoring user tool manifest")
+		print.DimStderr(err.Error())
+	}
 }
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"github.com/jterrazz/jterrazz-cli/src/internal/config"
-	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
 	"github.com/spf13/cobra"
 )
 
@@ -34,10 +33,8 @@ func createSubcommand(sub config.RunSubcommand) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   sub.Name,
 		Short: sub.Description,
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := sub.RunFn(args); err != nil {
-				print.Error(err.Error())
-			}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sub.RunFn(args)
 		},
 	}
 
@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/jterrazz/jterrazz-cli/src/internal/config"
 	"github.com/jterrazz/jterrazz-cli/src/internal/domain/skill"
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/components"
 	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
 	setupview "github.com/jterrazz/jterrazz-cli/src/internal/presentation/views/setup"
@@ -10,38 +13,124 @@ import (
 )
 
 var setupCmd = &cobra.Command{
-	Use:   "setup",
-	Short: "Setup system configurations (interactive)",
-	Run: func(cmd *cobra.Command, args []string) {
-		setupview.RunOrExit(runScript)
+	Use:         "setup",
+	Annotations: loggedRun,
+	Short:       "Setup system configurations (interactive)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if setupProfile != "" {
+			profile, err := config.FindProfile(setupProfile)
+			if err != nil {
+				return err
+			}
+			steps := config.PlanProfileSetup(profile, skill.ListInstalled())
+			if setupDryRun {
+				printPlan("setup "+profile.Name, steps)
+				return nil
+			}
+			return applyProfileSetup(profile, steps)
+		}
+
+		if setupDryRun {
+			printPlan("setup", config.PlanSetup())
+			return nil
+		}
+		return components.Run(setupview.SetupConfig(runScript))
 	},
 }
 
+var (
+	setupDryRun  bool
+	setupProfile string
+)
+
 func init() {
+	setupCmd.Flags().BoolVar(&setupDryRun, "dry-run", false, "Print what each setup script would do without running it")
+	setupCmd.Flags().StringVar(&setupProfile, "profile", "", "Run the setup scripts and install the skills of a profile, without the TUI")
+	setupCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
 	rootCmd.AddCommand(setupCmd)
 }
 
-// runScript runs a script by name
+// applyProfileSetup runs the steps planned by PlanProfileSetup: setup scripts
+// not configured yet, then favorite skills not installed yet
+func applyProfileSetup(profile config.Profile, steps []config.PlanStep) error {
+	print.Action("⚙️", "Setting up the "+profile.Name+" profile...")
+	repos := make(map[string]string)
+	for _, s := range profile.FavoriteSkills() {
+		repos[s.Skill] = s.Repo
+	}
+
+	var pending []config.PlanStep
+	for _, step := range steps {
+		if step.Skip != "" {
+			print.Row(true, step.Name, step.Skip)
+			continue
+		}
+		pending = append(pending, step)
+	}
+
+	var errs []error
+	for _, step := range pending {
+		var err error
+		if step.Method == "skill" {
+			err = installProfileSkill(repos[step.Name], step.Name)
+		} else {
+			err = runSetupItem(step.Name)
+		}
+		if err != nil {
+			if len(pending) > 1 {
+				print.Error(err.Error())
+			}
+			errs = append(errs, err)
+		}
+	}
+	if err := config.NewBatchError(len(pending), errs); err != nil {
+		return err
+	}
+	print.Done("Done")
+	return nil
+}
+
+// installProfileSkill installs a favorite skill with the skills CLI
+func installProfileSkill(repo, name string) error {
+	if !skill.IsInstalled() {
+		return config.MissingDependencyError("skills CLI not installed. Run: j install skills")
+	}
+	if err := skill.Install(repo, name); err != nil {
+		return fmt.Errorf("failed to install skill %s: %w", name, err)
+	}
+	print.Row(true, name, "skill installed")
+	return nil
+}
+
+// runScript runs a script by name, printing failures (used by the setup TUI)
 func runScript(name string) {
+	if err := runSetupItem(name); err != nil {
+		print.Error(err.Error())
+	}
+}
+
+// runSetupItem runs a setup item by name (used by install command for Tool.Scripts)
+func runSetupItem(name string) error {
 	script := config.GetScriptByName(name)
 	if script == nil {
-		print.Error("Unknown script: " + name)
-		return
+		return config.UnknownError("script", name)
 	}
 
+	if script.RunFn == nil && len(script.ExecArgs) > 0 {
+		// Interactive scripts get the terminal, as in the setup TUI
+		if err := tool.RunLoggedInteractive(script.ExecArgs[0], script.ExecArgs[1:]...); err != nil {
+			return fmt.Errorf("failed to run %s: %w", name, err)
+		}
+		return nil
+	}
 	if script.RunFn == nil {
-		print.Error("No runner for script: " + name)
-		return
+		return fmt.Errorf("no runner for script: %s", name)
 	}
 
 	if err := script.RunFn(); err != nil {
-		print.Error("Failed to run " + name + ": " + err.Error())
+		return fmt.Errorf("failed to run %s: %w", name, err)
 	}
-}
-
-// runSetupItem runs a setup item by name (used by install command for Tool.Scripts)
-func runSetupItem(name string) {
-	runScript(name)
+	return nil
 }
 
 // runSkillsUI runs the skills management UI
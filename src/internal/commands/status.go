@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
 	statusview "github.com/jterrazz/jterrazz-cli/src/internal/presentation/views/status"
 	"github.com/spf13/cobra"
 )
@@ -8,11 +9,49 @@ import (
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show comprehensive system status",
-	Run: func(cmd *cobra.Command, args []string) {
-		statusview.RunOrExit()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var profile *config.Profile
+		if statusProfile != "" {
+			p, err := config.FindProfile(statusProfile)
+			if err != nil {
+				return err
+			}
+			profile = &p
+		}
+
+		cache := openCheckCache(statusRefresh)
+		defer cache.Save()
+		return statusview.Run(cache, profile)
 	},
 }
 
+var (
+	statusRefresh bool
+	statusProfile string
+)
+
 func init() {
+	statusCmd.Flags().BoolVar(&statusRefresh, "refresh", false, "Ignore cached tool checks and check every tool again")
+	statusCmd.Flags().StringVar(&statusProfile, "profile", "", "Only report on the tools and setup scripts of a profile")
+	statusCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
 	rootCmd.AddCommand(statusCmd)
 }
+
+// openCheckCache opens the tool check cache, emptied with --refresh
+func openCheckCache(refresh bool) *config.CheckCache {
+	cache := config.OpenCheckCache(config.CheckCachePath())
+	if refresh {
+		cache.Clear()
+	}
+	return cache
+}
+
+// completeProfiles completes --profile with the built-in and user profiles
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	profiles, _ := config.GetProfiles()
+	var names []string
+	for _, p := range profiles {
+		names = append(names, p.Name+"\t"+p.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
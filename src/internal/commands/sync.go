@@ -1,13 +1,14 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
 	"github.com/spf13/cobra"
 )
@@ -15,8 +16,9 @@ import (
 var syncAllFlag bool
 
 var syncCmd = &cobra.Command{
-	Use:   "sync",
-	Short: "Sync project with copier templates",
+	Use:         "sync",
+	Annotations: loggedRun,
+	Short:       "Sync project with copier templates",
 	Long: `Sync the current project with copier templates.
 
 Running without a subcommand updates the current project from its template.
@@ -26,36 +28,36 @@ Examples:
   j sync init         Initialize a project from a template
   j sync status       Show template link status
   j sync diff         Preview changes before updating`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if syncAllFlag {
-			syncAllProjects()
-			return
+			return syncAllProjects()
 		}
-		syncUpdate()
+		return syncUpdate()
 	},
 }
 
 var syncInitCmd = &cobra.Command{
-	Use:   "init",
-	Short: "Initialize project from template",
-	Run: func(cmd *cobra.Command, args []string) {
-		syncInit()
+	Use:         "init",
+	Annotations: loggedRun,
+	Short:       "Initialize project from template",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return syncInit()
 	},
 }
 
 var syncStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show template link status",
-	Run: func(cmd *cobra.Command, args []string) {
-		syncStatus()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return syncStatus()
 	},
 }
 
 var syncDiffCmd = &cobra.Command{
 	Use:   "diff",
 	Short: "Preview changes before updating",
-	Run: func(cmd *cobra.Command, args []string) {
-		syncDiff()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return syncDiff()
 	},
 }
 
@@ -78,15 +80,17 @@ func hasCopierAnswers() bool {
 	return err == nil
 }
 
-// requireCopier checks that copier is installed and exits with a message if not
-func requireCopier() bool {
-	if _, err := exec.LookPath("copier"); err != nil {
-		print.Error("copier not installed. Run: j install copier")
-		return false
+// requireCopier checks that copier is installed
+func requireCopier() error {
+	if _, err := tool.LookPath("copier"); err != nil {
+		return config.MissingDependencyError("copier not installed. Run: j install copier")
 	}
-	return true
+	return nil
 }
 
+// errNotLinked is returned by commands that need a project linked to a template
+var errNotLinked = errors.New("no .copier-answers.yml found in current directory. Run: j sync init")
+
 // detectLanguage tries to detect the project language from files in the current directory
 func detectLanguage() string {
 	if _, err := os.Stat("go.mod"); err == nil {
@@ -99,48 +103,41 @@ func detectLanguage() string {
 }
 
 // syncUpdate runs copier update on the current project
-func syncUpdate() {
+func syncUpdate() error {
 	if !hasCopierAnswers() {
-		print.Warning("No .copier-answers.yml found in current directory")
-		print.Dim("Run 'j sync init' to initialize this project from a template")
-		return
+		return errNotLinked
 	}
 
-	if !requireCopier() {
-		return
+	if err := requireCopier(); err != nil {
+		return err
 	}
 
 	print.Action("🔄", "Updating project from template...")
 
-	cmd := exec.Command("copier", "update", "--trust")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
-		print.Error("Update failed: " + err.Error())
-		return
+	if err := tool.RunLoggedInteractive("copier", "update", "--trust"); err != nil {
+		return fmt.Errorf("update failed: %w", err)
 	}
 
 	print.Done("Project updated")
+	return nil
 }
 
 // syncInit initializes a project from the copier template
-func syncInit() {
-	if !requireCopier() {
-		return
+func syncInit() error {
+	if err := requireCopier(); err != nil {
+		return err
 	}
 
 	if hasCopierAnswers() {
 		print.Warning("Project already linked to a template (.copier-answers.yml exists)")
 		print.Dim("Run 'j sync' to update instead")
-		return
+		return nil
 	}
 
 	templatePath, err := getTemplatePath()
 	if err != nil {
-		print.Error("Template not found: " + err.Error())
 		print.Dim("Make sure jterrazz-cli is cloned at ~/Developer/jterrazz-cli")
-		return
+		return fmt.Errorf("template not found: %w", err)
 	}
 
 	// Auto-detect language and show it
@@ -158,34 +155,29 @@ func syncInit() {
 		args = []string{"copy", "--trust", "--data", fmt.Sprintf("language=%s", lang), templatePath, "."}
 	}
 
-	cmd := exec.Command("copier", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
-		print.Error("Init failed: " + err.Error())
-		return
+	if err := tool.RunLoggedInteractive("copier", args...); err != nil {
+		return fmt.Errorf("init failed: %w", err)
 	}
 
 	print.Empty()
 	print.Done("Project initialized from template")
 	print.Dim("Run 'j sync' anytime to pull template updates")
+	return nil
 }
 
 // syncStatus shows the template link status for the current project
-func syncStatus() {
+func syncStatus() error {
 	if !hasCopierAnswers() {
 		print.Row(false, "Not linked", "no .copier-answers.yml")
 		print.Empty()
 		print.Dim("Run 'j sync init' to link this project to a template")
-		return
+		return nil
 	}
 
 	// Read and display the copier answers
 	data, err := os.ReadFile(".copier-answers.yml")
 	if err != nil {
-		print.Error("Failed to read .copier-answers.yml: " + err.Error())
-		return
+		return fmt.Errorf("failed to read .copier-answers.yml: %w", err)
 	}
 
 	print.Row(true, "Linked", ".copier-answers.yml")
@@ -211,44 +203,38 @@ func syncStatus() {
 		}
 	}
 	print.Empty()
+	return nil
 }
 
 // syncDiff previews what would change on the next update
-func syncDiff() {
+func syncDiff() error {
 	if !hasCopierAnswers() {
-		print.Warning("No .copier-answers.yml found in current directory")
-		print.Dim("Run 'j sync init' to initialize this project from a template")
-		return
+		return errNotLinked
 	}
 
-	if !requireCopier() {
-		return
+	if err := requireCopier(); err != nil {
+		return err
 	}
 
 	print.Action("🔍", "Previewing template changes...")
 	print.Empty()
 
-	cmd := exec.Command("copier", "update", "--pretend", "--diff", "--trust")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	if err := cmd.Run(); err != nil {
-		print.Error("Diff failed: " + err.Error())
-		return
+	if err := tool.Run("copier", "update", "--pretend", "--diff", "--trust"); err != nil {
+		return fmt.Errorf("diff failed: %w", err)
 	}
+	return nil
 }
 
 // syncAllProjects finds all projects in ~/Developer with .copier-answers.yml and updates them
-func syncAllProjects() {
-	if !requireCopier() {
-		return
+func syncAllProjects() error {
+	if err := requireCopier(); err != nil {
+		return err
 	}
 
 	devDir := os.Getenv("HOME") + "/Developer"
 	entries, err := os.ReadDir(devDir)
 	if err != nil {
-		print.Error("Failed to read ~/Developer: " + err.Error())
-		return
+		return fmt.Errorf("failed to read ~/Developer: %w", err)
 	}
 
 	var projects []string
@@ -264,27 +250,39 @@ func syncAllProjects() {
 
 	if len(projects) == 0 {
 		print.Dim("No projects with .copier-answers.yml found in ~/Developer")
-		return
+		return nil
 	}
 
 	print.Action("🔄", fmt.Sprintf("Updating %d projects...", len(projects)))
 	print.Empty()
 
+	var errs []error
 	for _, name := range projects {
 		projectDir := filepath.Join(devDir, name)
 		print.Info(name)
 
-		cmd := exec.Command("copier", "update", "--trust")
-		cmd.Dir = projectDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
+		update := tool.Cmd{
+			Name:   "copier",
+			Args:   []string{"update", "--trust"},
+			Dir:    projectDir,
+			Stdout: os.Stdout,
+			Stderr: os.Stderr,
+			Logged: true,
+			// copier asks about new template questions
+			Interactive: true,
+		}
+		if err := tool.RunCmd(update); err != nil {
 			print.Error("  Failed: " + err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
 		} else {
 			print.Success("  Updated")
 		}
 		print.Empty()
 	}
 
+	if len(errs) > 0 {
+		return config.NewBatchError(len(projects), errs)
+	}
 	print.Done(fmt.Sprintf("Updated %d projects", len(projects)))
+	return nil
 }
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
+	"github.com/spf13/cobra"
+)
+
+var (
+	uninstallForce bool
+	uninstallYes   bool
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:         "uninstall <tool...>",
+	Annotations: loggedRun,
+	Short:       "Uninstall development tools",
+	Long: `Uninstall development tools.
+
+Tools are removed with the package manager that installed them
+(brew uninstall, brew uninstall --cask --zap, bun remove -g, npm uninstall -g).
+A tool that an installed tool depends on is kept unless --force is given.
+
+Examples:
+  j uninstall lens           Uninstall a tool
+  j uninstall bun --force    Uninstall even if installed tools depend on it
+  j uninstall openjdk -y     Also revert its setup scripts without asking`,
+	Args: cobra.MinimumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var all []string
+		for _, t := range config.Tools {
+			all = append(append(all, t.Name), t.Aliases...)
+		}
+		return tool.FilterStrings(all, args), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		print.Action("🗑️", "Uninstalling selected tools...")
+		if err := runEach(args, uninstallToolByName); err != nil {
+			return err
+		}
+		print.Done("Done")
+		return nil
+	},
+}
+
+func init() {
+	uninstallCmd.Flags().BoolVarP(&uninstallForce, "force", "f", false, "Uninstall even if installed tools depend on it")
+	uninstallCmd.Flags().BoolVarP(&uninstallYes, "yes", "y", false, "Revert attached setup scripts without asking")
+	rootCmd.AddCommand(uninstallCmd)
+}
+
+func uninstallToolByName(name string) error {
+	t, err := config.FindTool(name)
+	if err != nil {
+		return err
+	}
+
+	if !t.Check().Installed {
+		print.Row(false, t.Name, "not installed")
+		return nil
+	}
+
+	if dependents := config.GetInstalledDependents(t.Name); len(dependents) > 0 {
+		names := make([]string, len(dependents))
+		for i, d := range dependents {
+			names[i] = d.Name
+		}
+		if !uninstallForce {
+			return fmt.Errorf("%s is required by %s. Use --force to uninstall anyway", t.Name, strings.Join(names, ", "))
+		}
+		print.Warning(t.Name + " is required by " + strings.Join(names, ", "))
+	}
+
+	print.Action("🗑️", "Uninstalling "+t.Name+"...")
+	if err := t.Uninstall(); err != nil {
+		return fmt.Errorf("failed to uninstall %s: %w", t.Name, err)
+	}
+	print.Row(true, t.Name, "uninstalled")
+
+	return revertToolScripts(*t)
+}
+
+// revertToolScripts offers to undo the configured scripts attached to a tool
+func revertToolScripts(t config.Tool) error {
+	var errs []error
+	for _, script := range config.GetScriptsForTool(t.Name) {
+		if script.RevertFn == nil || !config.CheckScript(script).Installed {
+			continue
+		}
+		if !uninstallYes && !confirm("Revert "+script.Name+" ("+script.Description+")?") {
+			continue
+		}
+		if err := script.RevertFn(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to revert %s: %w", script.Name, err))
+			continue
+		}
+		print.Row(true, script.Name, "reverted")
+	}
+	return errors.Join(errs...)
+}
@@ -10,69 +10,100 @@ import (
 var upgradeFlags = make(map[string]*bool)
 
 var upgradeCmd = &cobra.Command{
-	Use:   "upgrade [package...]",
-	Short: "Upgrade system packages",
+	Use:         "upgrade [package...]",
+	Annotations: loggedRunWithArgs,
+	Short:       "Upgrade system packages",
 	Long: `Upgrade system packages.
 
 Examples:
   j upgrade --all             Upgrade all package managers
+  j upgrade --all --dry-run   Show the upgrade commands without running them
+  j upgrade --check           List outdated tools without upgrading
   j upgrade --brew            Upgrade Homebrew packages only
   j upgrade --npm             Upgrade npm global packages only
   j upgrade --bun             Upgrade bun global packages only
+  j upgrade --cargo --go      Upgrade cargo crates and go binaries
+  j upgrade --mas             Upgrade Mac App Store apps
   j upgrade node              Upgrade specific brew package
   j upgrade claude opencode   Upgrade specific packages
   j upgrade                   List available options`,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		var all []string
 		for _, pkg := range config.Tools {
-			if pkg.Method == config.InstallBrewFormula || pkg.Method == config.InstallBrewCask {
-				all = append(all, pkg.Name)
+			switch pkg.Method {
+			case config.InstallBrewFormula, config.InstallBrewCask, config.InstallMAS, config.InstallCargo, config.InstallUv, config.InstallPipx, config.InstallGo:
+				all = append(append(all, pkg.Name), pkg.Aliases...)
 			}
 		}
 		return tool.FilterStrings(all, args), cobra.ShellCompDirectiveNoFileComp
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check for --all flag
 		allFlag, _ := cmd.Flags().GetBool("all")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			checkOutdated()
+			return nil
+		}
+		if allFlag && dryRun {
+			printPlan("upgrade", config.PlanUpgradeAll())
+			return nil
+		}
 		if allFlag {
 			print.Action("🔄", "Upgrading all packages...")
-			config.UpgradeAll()
+			if err := config.UpgradeAll(); err != nil {
+				return err
+			}
 			print.Done("All upgrades completed")
-			return
+			return nil
 		}
 
 		// Check for specific manager flags
-		anyFlagSet := false
+		var selected []string
 		for _, pm := range config.PackageManagers {
 			if flagVal, ok := upgradeFlags[pm.Flag]; ok && *flagVal {
-				anyFlagSet = true
-				config.UpgradePackageManager(pm)
+				selected = append(selected, pm.Flag)
+			}
+		}
+		if len(selected) > 0 && dryRun {
+			var steps []config.PlanStep
+			for _, flag := range selected {
+				steps = append(steps, config.PlanUpgrade(*config.GetPackageManagerByFlag(flag)))
 			}
+			printPlan("upgrade", steps)
+			return nil
 		}
-		if anyFlagSet {
+		if len(selected) > 0 {
+			err := runEach(selected, func(flag string) error {
+				return config.UpgradePackageManager(*config.GetPackageManagerByFlag(flag))
+			})
+			if err != nil {
+				return err
+			}
 			print.Done("Upgrades completed")
-			return
+			return nil
 		}
 
 		// If specific package names provided
 		if len(args) > 0 {
 			print.Action("🔄", "Upgrading selected packages...")
-			for _, name := range args {
-				if err := config.UpgradePackageByName(name); err != nil {
-					print.Error(err.Error())
-				}
+			if err := runEach(args, config.UpgradePackageByName); err != nil {
+				return err
 			}
 			print.Done("Upgrades completed")
-			return
+			return nil
 		}
 
 		// No args, list options
 		listUpgradeOptions()
+		return nil
 	},
 }
 
 func init() {
 	upgradeCmd.Flags().BoolP("all", "a", false, "Upgrade all package managers")
+	upgradeCmd.Flags().Bool("dry-run", false, "Print the upgrade plan without running it")
+	upgradeCmd.Flags().Bool("check", false, "List outdated tools (current and latest versions) without upgrading")
 
 	// Dynamically add flags for each package manager
 	for _, pm := range config.PackageManagers {
@@ -84,6 +115,28 @@ func init() {
 	rootCmd.AddCommand(upgradeCmd)
 }
 
+// checkOutdated prints the registry tools with a newer version available
+func checkOutdated() {
+	print.Action("🔍", "Checking for outdated tools...")
+	outdated, err := config.CheckOutdated(config.Tools)
+	if err != nil {
+		print.Warning(err.Error())
+	}
+
+	if len(outdated) == 0 {
+		print.Done("Everything is up to date")
+		return
+	}
+	for _, o := range outdated {
+		print.Row(false, o.Tool.Name, o.Current+" → "+o.Latest)
+	}
+	print.Empty()
+	print.Usage(
+		"Upgrade with: j upgrade <tool> [tool...]",
+		"          or: j upgrade --all",
+	)
+}
+
 func listUpgradeOptions() {
 	print.Info("Available upgrade targets:")
 	print.Empty()
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BrewfileEntry is one tap, brew, cask or mas line of a Brewfile
+type BrewfileEntry struct {
+	Kind string // "tap", "brew", "cask" or "mas"
+	Name string // Tap, formula, cask or App Store app name
+	ID   int    // App Store id, for mas lines
+}
+
+// brewfileLine matches `brew "go"`, `cask 'zed', args: {...}`, `mas "Xcode", id: 497799835`
+var brewfileLine = regexp.MustCompile(`^(tap|brew|cask|mas)\s+["']([^"']+)["']\s*(.*)$`)
+
+var brewfileMasID = regexp.MustCompile(`\bid:\s*(\d+)`)
+
+// ParseBrewfile reads the tap, brew, cask and mas lines of a Brewfile.
+// Other directives (vscode, whalebrew, cask_args...) are returned as skipped.
+func ParseBrewfile(data string) (entries []BrewfileEntry, skipped []string, err error) {
+	for i, raw := range strings.Split(data, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		m := brewfileLine.FindStringSubmatch(line)
+		if m == nil {
+			skipped = append(skipped, line)
+			continue
+		}
+
+		entry := BrewfileEntry{Kind: m[1], Name: m[2]}
+		if entry.Kind == "mas" {
+			id := brewfileMasID.FindStringSubmatch(m[3])
+			if id == nil {
+				return nil, nil, fmt.Errorf("line %d: mas %q has no id", i+1, entry.Name)
+			}
+			entry.ID, _ = strconv.Atoi(id[1])
+		}
+		entries = append(entries, entry)
+	}
+	return entries, skipped, nil
+}
+
+// ExportBrewfile renders the brew, cask and mas tools as a Brewfile, each
+// line marked with whether it is installed on this machine
+func ExportBrewfile(tools []Tool, installed map[string]bool) string {
+	var taps, lines []string
+	seenTaps := make(map[string]bool)
+
+	mark := func(line string, t Tool) string {
+		if installed[t.Name] {
+			return line + " # installed"
+		}
+		return line + " # not installed"
+	}
+
+	for _, kind := range []InstallMethod{InstallBrewFormula, InstallBrewCask, InstallMAS} {
+		for _, t := range tools {
+			if t.Method != kind {
+				continue
+			}
+			switch kind {
+			case InstallMAS:
+				if t.AppStoreID == 0 {
+					lines = append(lines, fmt.Sprintf("# mas %q: no App Store id in the registry", appStoreName(t)))
+					continue
+				}
+				lines = append(lines, mark(fmt.Sprintf("mas %q, id: %d", appStoreName(t), t.AppStoreID), t))
+			default:
+				if tap := formulaTap(t.Formula); tap != "" && !seenTaps[tap] {
+					seenTaps[tap] = true
+					taps = append(taps, fmt.Sprintf("tap %q", tap))
+				}
+				lines = append(lines, mark(fmt.Sprintf("%s %q", brewfileKind(kind), t.Formula), t))
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Generated by j export brewfile\n")
+	if len(taps) > 0 {
+		b.WriteString("\n" + strings.Join(taps, "\n") + "\n")
+	}
+	if len(lines) > 0 {
+		b.WriteString("\n" + strings.Join(lines, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// BrewfileImport is the outcome of mapping Brewfile entries onto the registry
+type BrewfileImport struct {
+	Entries []ToolManifestEntry // New manifest entries
+	Skipped []string            // Why the other lines were not imported
+}
+
+// ImportBrewfile turns Brewfile entries into manifest entries. Entries
+// already covered by tools or by the manifest are skipped. Tap lines need no
+// entry: tap-qualified formulae (user/tap/formula) tap on install.
+func ImportBrewfile(entries []BrewfileEntry, tools []Tool, manifest ToolManifest) BrewfileImport {
+	var result BrewfileImport
+
+	taken := make(map[string]bool, len(tools)+len(manifest.Tools))
+	for _, t := range tools {
+		taken[t.Name] = true
+	}
+	for _, e := range manifest.Tools {
+		taken[e.Name] = true
+	}
+
+	usedTaps := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Kind == "brew" || entry.Kind == "cask" {
+			usedTaps[formulaTap(entry.Name)] = true
+		}
+	}
+
+	for _, entry := range entries {
+		label := fmt.Sprintf("%s %q", entry.Kind, entry.Name)
+		if entry.Kind == "tap" {
+			if !usedTaps[entry.Name] {
+				result.Skipped = append(result.Skipped, label+": declare its formulae as "+entry.Name+"/<formula>")
+			}
+			continue
+		}
+
+		if existing := findBrewfileTool(entry, tools, manifest); existing != "" {
+			result.Skipped = append(result.Skipped, label+": already tracked as "+existing)
+			continue
+		}
+
+		e := ToolManifestEntry{Formula: entry.Name}
+		switch entry.Kind {
+		case "brew":
+			e.Name, e.Method, e.Category = path.Base(entry.Name), string(InstallBrewFormula), string(CategoryTerminalGit)
+		case "cask":
+			e.Name, e.Method, e.Category = path.Base(entry.Name), string(InstallBrewCask), string(CategoryGUIApps)
+		case "mas":
+			e.Name, e.Method, e.Category = appStoreSlug(entry.Name), string(InstallMAS), string(CategoryMacAppStore)
+			e.AppStoreID = entry.ID
+		}
+		if taken[e.Name] {
+			result.Skipped = append(result.Skipped, label+": the name "+e.Name+" is already used by another tool")
+			continue
+		}
+		taken[e.Name] = true
+		result.Entries = append(result.Entries, e)
+	}
+	return result
+}
+
+// findBrewfileTool returns the name of the tool or manifest entry already installing entry
+func findBrewfileTool(entry BrewfileEntry, tools []Tool, manifest ToolManifest) string {
+	method := brewfileMethod(entry.Kind)
+	matches := func(m InstallMethod, formula string, id int) bool {
+		if m != method {
+			return false
+		}
+		if method == InstallMAS {
+			return id == entry.ID
+		}
+		return formula == entry.Name
+	}
+
+	for _, t := range tools {
+		if matches(t.Method, t.Formula, t.AppStoreID) {
+			return t.Name
+		}
+		// Built-in App Store apps without an id are matched by name
+		if method == InstallMAS && t.Method == InstallMAS && t.AppStoreID == 0 && t.Name == appStoreSlug(entry.Name) {
+			return t.Name
+		}
+	}
+	for _, e := range manifest.Tools {
+		if matches(InstallMethod(e.Method), e.Formula, e.AppStoreID) {
+			return e.Name
+		}
+	}
+	return ""
+}
+
+func brewfileKind(m InstallMethod) string {
+	if m == InstallBrewCask {
+		return "cask"
+	}
+	return "brew"
+}
+
+func brewfileMethod(kind string) InstallMethod {
+	switch kind {
+	case "cask":
+		return InstallBrewCask
+	case "mas":
+		return InstallMAS
+	default:
+		return InstallBrewFormula
+	}
+}
+
+// formulaTap returns the tap of a qualified formula ("tw93/tap/mole" -> "tw93/tap")
+func formulaTap(formula string) string {
+	parts := strings.Split(formula, "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// appStoreName returns the App Store name of a mas tool (App, else Formula, else Name)
+func appStoreName(t Tool) string {
+	if t.App != "" {
+		return t.App
+	}
+	if t.Formula != "" {
+		return t.Formula
+	}
+	return t.Name
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// appStoreSlug turns an app name into a tool name ("Final Cut Pro" -> "final-cut-pro")
+func appStoreSlug(name string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
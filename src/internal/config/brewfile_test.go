@@ -0,0 +1,131 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBrewfile(t *testing.T) {
+	given := `# Team Brewfile
+tap "tw93/tap"
+brew "go"
+brew 'tw93/tap/mole', restart_service: :changed
+cask "zed" # editor
+mas "Final Cut Pro", id: 424389933
+vscode "golang.go"
+`
+	entries, skipped, err := ParseBrewfile(given)
+	if err != nil {
+		t.Fatalf("ParseBrewfile() error = %v", err)
+	}
+
+	expected := []BrewfileEntry{
+		{Kind: "tap", Name: "tw93/tap"},
+		{Kind: "brew", Name: "go"},
+		{Kind: "brew", Name: "tw93/tap/mole"},
+		{Kind: "cask", Name: "zed"},
+		{Kind: "mas", Name: "Final Cut Pro", ID: 424389933},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("ParseBrewfile() entries = %+v, want %+v", entries, expected)
+	}
+	if !reflect.DeepEqual(skipped, []string{`vscode "golang.go"`}) {
+		t.Errorf("ParseBrewfile() skipped = %v", skipped)
+	}
+
+	if _, _, err := ParseBrewfile(`mas "Xcode"`); err == nil {
+		t.Error("expected an error for a mas line without id")
+	}
+}
+
+func TestExportBrewfile(t *testing.T) {
+	tools := []Tool{
+		{Name: "homebrew", Method: InstallManual},
+		{Name: "zed", Method: InstallBrewCask, Formula: "zed"},
+		{Name: "go", Method: InstallBrewFormula, Formula: "go"},
+		{Name: "mole", Method: InstallBrewFormula, Formula: "tw93/tap/mole"},
+		{Name: "xcode", Method: InstallMAS, Formula: "Xcode", AppStoreID: 497799835},
+		{Name: "broadcasts", Method: InstallMAS},
+	}
+	installed := map[string]bool{"go": true, "xcode": true}
+
+	expected := `# Generated by j export brewfile
+
+tap "tw93/tap"
+
+brew "go" # installed
+brew "tw93/tap/mole" # not installed
+cask "zed" # not installed
+mas "Xcode", id: 497799835 # installed
+# mas "broadcasts": no App Store id in the registry
+`
+	if got := ExportBrewfile(tools, installed); got != expected {
+		t.Errorf("ExportBrewfile() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestImportBrewfile(t *testing.T) {
+	tools := []Tool{
+		{Name: "go", Method: InstallBrewFormula, Formula: "go"},
+		{Name: "compressor", Method: InstallMAS},
+		{Name: "zed", Method: InstallBrewFormula, Formula: "zed-cli"},
+	}
+	manifest := ToolManifest{Tools: []ToolManifestEntry{{Name: "jq", Method: "brew", Formula: "jq"}}}
+	entries := []BrewfileEntry{
+		{Kind: "tap", Name: "tw93/tap"},
+		{Kind: "tap", Name: "unused/tap"},
+		{Kind: "brew", Name: "go"},
+		{Kind: "brew", Name: "jq"},
+		{Kind: "brew", Name: "tw93/tap/mole"},
+		{Kind: "cask", Name: "zed"},
+		{Kind: "cask", Name: "raycast"},
+		{Kind: "mas", Name: "Compressor", ID: 424390742},
+		{Kind: "mas", Name: "Final Cut Pro", ID: 424389933},
+	}
+
+	got := ImportBrewfile(entries, tools, manifest)
+
+	expected := []ToolManifestEntry{
+		{Name: "mole", Category: "Terminal & Git", Method: "brew", Formula: "tw93/tap/mole"},
+		{Name: "raycast", Category: "GUI Apps", Method: "cask", Formula: "raycast"},
+		{Name: "final-cut-pro", Category: "Mac App Store", Method: "mas", Formula: "Final Cut Pro", AppStoreID: 424389933},
+	}
+	if !reflect.DeepEqual(got.Entries, expected) {
+		t.Errorf("ImportBrewfile() entries = %+v, want %+v", got.Entries, expected)
+	}
+
+	skipped := strings.Join(got.Skipped, "\n")
+	for _, want := range []string{
+		`tap "unused/tap"`,
+		`brew "go": already tracked as go`,
+		`brew "jq": already tracked as jq`,
+		`cask "zed": the name zed is already used`,
+		`mas "Compressor": already tracked as compressor`,
+	} {
+		if !strings.Contains(skipped, want) {
+			t.Errorf("ImportBrewfile() skipped = %v, want %q", got.Skipped, want)
+		}
+	}
+	if strings.Contains(skipped, `tap "tw93/tap"`) {
+		t.Errorf("a tap used by an imported formula should not be skipped: %v", got.Skipped)
+	}
+}
+
+func TestBrewfileRoundtrip(t *testing.T) {
+	manifest := ToolManifest{Tools: []ToolManifestEntry{
+		{Name: "mole", Category: "Terminal & Git", Method: "brew", Formula: "tw93/tap/mole"},
+		{Name: "raycast", Category: "GUI Apps", Method: "cask", Formula: "raycast"},
+		{Name: "final-cut-pro", Category: "Mac App Store", Method: "mas", Formula: "Final Cut Pro", AppStoreID: 424389933},
+	}}
+	tools := MergeTools(nil, manifest)
+
+	entries, _, err := ParseBrewfile(ExportBrewfile(tools, nil))
+	if err != nil {
+		t.Fatalf("ParseBrewfile() error = %v", err)
+	}
+	got := ImportBrewfile(entries, nil, ToolManifest{})
+	if !reflect.DeepEqual(got.Entries, manifest.Tools) {
+		t.Errorf("roundtrip = %+v, want %+v", got.Entries, manifest.Tools)
+	}
+}
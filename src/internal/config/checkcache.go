@@ -0,0 +1,173 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// CheckCacheTTL is how long a cached check stays valid while its files are unchanged
+const CheckCacheTTL = time.Hour
+
+// CheckCachePath is where check results are cached between runs
+func CheckCachePath() string {
+	return filepath.Join(os.Getenv("HOME"), ".cache", "jterrazz", "checks.json")
+}
+
+// CheckCache keeps tool CheckResults on disk, keyed by tool name. An entry is
+// valid until its TTL expires or the files it was computed from change: the
+// resolved command binary, the app bundle, the brew keg or cask.
+type CheckCache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]checkCacheEntry
+	dirty   bool
+}
+
+type checkCacheEntry struct {
+	Result      CheckResult `json:"result"`
+	Fingerprint string      `json:"fingerprint"`
+	CheckedAt   time.Time   `json:"checked_at"`
+}
+
+// OpenCheckCache loads the cache at path. A missing or unreadable file gives
+// an empty cache: the checks run again and rewrite it.
+func OpenCheckCache(path string) *CheckCache {
+	c := &CheckCache{path: path, ttl: CheckCacheTTL, now: time.Now, entries: make(map[string]checkCacheEntry)}
+	if data, err := os.ReadFile(path); err == nil {
+		if json.Unmarshal(data, &c.entries) != nil {
+			c.entries = make(map[string]checkCacheEntry)
+		}
+	}
+	return c
+}
+
+// Peek returns the cached result of the tool, even when it is no longer valid
+func (c *CheckCache) Peek(t Tool) (CheckResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[t.Name]
+	return entry.Result, ok
+}
+
+// Get returns the cached result of the tool while it is valid
+func (c *CheckCache) Get(t Tool) (CheckResult, bool) {
+	fingerprint, ok := t.checkFingerprint()
+	if !ok {
+		return CheckResult{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[t.Name]
+	if !ok || entry.Fingerprint != fingerprint || c.now().Sub(entry.CheckedAt) >= c.ttl {
+		return CheckResult{}, false
+	}
+	return entry.Result, true
+}
+
+// Put caches the result of the tool. Tools whose check depends on nothing
+// a fingerprint can see are not cached.
+func (c *CheckCache) Put(t Tool, result CheckResult) {
+	fingerprint, ok := t.checkFingerprint()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !ok {
+		if _, cached := c.entries[t.Name]; cached {
+			delete(c.entries, t.Name)
+			c.dirty = true
+		}
+		return
+	}
+	c.entries[t.Name] = checkCacheEntry{Result: result, Fingerprint: fingerprint, CheckedAt: c.now()}
+	c.dirty = true
+}
+
+// Check returns the valid cached result of the tool, or checks it and caches the result
+func (c *CheckCache) Check(t Tool) CheckResult {
+	if result, ok := c.Get(t); ok {
+		return result
+	}
+	result := t.Check()
+	c.Put(t, result)
+	return result
+}
+
+// Clear drops every entry (--refresh)
+func (c *CheckCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]checkCacheEntry)
+	c.dirty = true
+}
+
+// Save writes the cache when it changed
+func (c *CheckCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(c.path), err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// checkFingerprint describes the files the check of the tool reads, with
+// their modification times. It is false when there is none to watch.
+func (t Tool) checkFingerprint() (string, bool) {
+	var files []string
+	if t.Command != "" {
+		binary, err := tool.LookPath(t.Command)
+		if err != nil {
+			binary, _ = t.releaseBinary()
+		}
+		if binary == "" {
+			files = append(files, "command:"+t.Command) // Not found: changes once it is
+		} else {
+			files = append(files, fileStamp(binary))
+		}
+	}
+	if t.App != "" {
+		files = append(files, fileStamp(filepath.Join("/Applications", t.App+".app")))
+	}
+	switch t.Method {
+	case InstallBrewFormula:
+		files = append(files, fileStamp(filepath.Join(BrewPrefix(), "Cellar", path.Base(t.Formula))))
+	case InstallBrewCask:
+		files = append(files, fileStamp(filepath.Join(BrewPrefix(), "Caskroom", path.Base(t.Formula))))
+	}
+	if len(files) == 0 {
+		return "", false
+	}
+	return strings.Join(files, "|"), true
+}
+
+// fileStamp identifies a file by its resolved path and modification time
+func fileStamp(name string) string {
+	if resolved, err := filepath.EvalSymlinks(name); err == nil {
+		name = resolved
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		return name + "@missing"
+	}
+	return fmt.Sprintf("%s@%d", name, info.ModTime().UnixNano())
+}
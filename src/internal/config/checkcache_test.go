@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckCache(t *testing.T) {
+	prefix := t.TempDir()
+	t.Setenv("HOMEBREW_PREFIX", prefix)
+	keg := filepath.Join(prefix, "Cellar", "jq")
+	if err := os.MkdirAll(keg, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := 0
+	jq := Tool{Name: "jq", Method: InstallBrewFormula, Formula: "jq", CheckFn: func() CheckResult {
+		checks++
+		return InstalledWithVersion("1.7.1")
+	}}
+
+	path := filepath.Join(t.TempDir(), "checks.json")
+	cache := OpenCheckCache(path)
+	if _, ok := cache.Peek(jq); ok {
+		t.Fatal("Peek() on an empty cache found an entry")
+	}
+	if got := cache.Check(jq); got.Version != "1.7.1" || checks != 1 {
+		t.Fatalf("Check() = %+v after %d checks, want 1.7.1 after 1", got, checks)
+	}
+	cache.Check(jq)
+	if checks != 1 {
+		t.Errorf("Check() of a cached tool ran %d checks, want 1", checks)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reloaded from disk
+	cache = OpenCheckCache(path)
+	if got, ok := cache.Get(jq); !ok || got.Version != "1.7.1" {
+		t.Errorf("Get() after reload = %+v, %v, want 1.7.1", got, ok)
+	}
+
+	// Upgrading the keg invalidates the entry, but Peek still shows it
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(keg, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(jq); ok {
+		t.Error("Get() after the keg changed = valid, want stale")
+	}
+	if got, ok := cache.Peek(jq); !ok || got.Version != "1.7.1" {
+		t.Errorf("Peek() of a stale entry = %+v, %v, want 1.7.1", got, ok)
+	}
+	cache.Check(jq)
+	if checks != 2 {
+		t.Errorf("Check() of a stale tool ran %d checks, want 2", checks)
+	}
+
+	// The TTL expires
+	cache.now = func() time.Time { return time.Now().Add(CheckCacheTTL) }
+	if _, ok := cache.Get(jq); ok {
+		t.Error("Get() after the TTL = valid, want expired")
+	}
+
+	// Nothing to fingerprint: never cached
+	manual := Tool{Name: "manual", Method: InstallManual, CheckFn: func() CheckResult { return Installed() }}
+	cache.Check(manual)
+	if _, ok := cache.Peek(manual); ok {
+		t.Error("Check() cached a tool without files to watch")
+	}
+}
+
+func TestOpenCheckCacheCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checks.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := OpenCheckCache(path)
+	if _, ok := cache.Peek(Tool{Name: "jq"}); ok {
+		t.Error("Peek() on a corrupt cache found an entry")
+	}
+}
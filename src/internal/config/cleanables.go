@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 )
 
 // Cleanable represents reclaimable storage that can be cleaned
@@ -19,7 +23,8 @@ type Cleanable struct {
 	SizeFn func() int64 // Get current size in bytes
 
 	// Clean
-	CleanFn func() error // How to clean it
+	Commands [][]string   // Commands to run in order (used when CleanFn is nil)
+	CleanFn  func() error // Custom clean (overrides Commands)
 
 	// Dependencies
 	RequiresCmd string // Only show if this command exists (e.g., "docker")
@@ -31,12 +36,7 @@ var Cleanables = []Cleanable{
 		Name:        "brew",
 		Description: "Clean Homebrew cache",
 		RequiresCmd: "brew",
-		CleanFn: func() error {
-			cmd := exec.Command("brew", "cleanup")
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			return cmd.Run()
-		},
+		Commands:    [][]string{{"brew", "cleanup"}},
 		SizeFn: func() int64 {
 			return GetDirSize(os.Getenv("HOME") + "/Library/Caches/Homebrew")
 		},
@@ -45,33 +45,21 @@ var Cleanables = []Cleanable{
 		Name:        "docker",
 		Description: "Clean Docker containers, images, volumes",
 		RequiresCmd: "docker",
-		CleanFn: func() error {
-			commands := [][]string{
-				{"docker", "container", "prune", "-f"},
-				{"docker", "image", "prune", "-f"},
-				{"docker", "volume", "prune", "-f"},
-				{"docker", "network", "prune", "-f"},
-				{"docker", "builder", "prune", "-f"},
-			}
-			for _, args := range commands {
-				cmd := exec.Command(args[0], args[1:]...)
-				cmd.Stdout = os.Stdout
-				cmd.Stderr = os.Stderr
-				cmd.Run()
-			}
-			return nil
+		Commands: [][]string{
+			{"docker", "container", "prune", "-f"},
+			{"docker", "image", "prune", "-f"},
+			{"docker", "volume", "prune", "-f"},
+			{"docker", "network", "prune", "-f"},
+			{"docker", "builder", "prune", "-f"},
 		},
 	},
 	{
 		Name:        "multipass",
 		Description: "Remove all Multipass instances",
 		RequiresCmd: "multipass",
-		CleanFn: func() error {
-			exec.Command("multipass", "delete", "--all").Run()
-			cmd := exec.Command("multipass", "purge")
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			return cmd.Run()
+		Commands: [][]string{
+			{"multipass", "delete", "--all"},
+			{"multipass", "purge"},
 		},
 		SizeFn: func() int64 {
 			return GetDirSize(os.Getenv("HOME") + "/Library/Application Support/multipassd")
@@ -95,6 +83,21 @@ var Cleanables = []Cleanable{
 // Cleanable Functions
 // =============================================================================
 
+// Clean runs CleanFn, or each of Commands in order.
+// A failing command does not stop the following ones; all failures are returned.
+func (c Cleanable) Clean() error {
+	if c.CleanFn != nil {
+		return c.CleanFn()
+	}
+	var errs []error
+	for _, args := range c.Commands {
+		if err := tool.RunLogged(args[0], args[1:]...); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", strings.Join(args, " "), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GetAllCleanables returns all cleanables
 func GetAllCleanables() []Cleanable {
 	return Cleanables
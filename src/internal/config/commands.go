@@ -2,9 +2,9 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 )
 
 // RunCommand represents a group of related subcommands under `j run`
@@ -35,7 +35,7 @@ var RunCommands = []RunCommand{
 				Name:        "rm",
 				Description: "Remove all containers",
 				RunFn: func(args []string) error {
-					out, err := exec.Command("docker", "ps", "-aq").Output()
+					out, err := tool.Output("docker", "ps", "-aq")
 					if err != nil || strings.TrimSpace(string(out)) == "" {
 						fmt.Println("No containers to remove")
 						return nil
@@ -48,7 +48,7 @@ var RunCommands = []RunCommand{
 				Name:        "rmi",
 				Description: "Remove all images",
 				RunFn: func(args []string) error {
-					out, err := exec.Command("docker", "images", "-aq").Output()
+					out, err := tool.Output("docker", "images", "-aq")
 					if err != nil || strings.TrimSpace(string(out)) == "" {
 						fmt.Println("No images to remove")
 						return nil
@@ -69,13 +69,13 @@ var RunCommands = []RunCommand{
 				Description: "Remove all containers and images",
 				RunFn: func(args []string) error {
 					// Remove containers (ignore errors, continue cleaning)
-					out, _ := exec.Command("docker", "ps", "-aq").Output()
+					out, _ := tool.Output("docker", "ps", "-aq")
 					if strings.TrimSpace(string(out)) != "" {
 						containers := strings.Fields(string(out))
 						_ = ExecCommand("docker", append([]string{"rm", "-vf"}, containers...)...)
 					}
 					// Remove images (ignore errors, continue cleaning)
-					out, _ = exec.Command("docker", "images", "-aq").Output()
+					out, _ = tool.Output("docker", "images", "-aq")
 					if strings.TrimSpace(string(out)) != "" {
 						images := strings.Fields(string(out))
 						_ = ExecCommand("docker", append([]string{"rmi", "-f"}, images...)...)
@@ -173,11 +173,7 @@ var RunCommands = []RunCommand{
 
 // ExecCommand runs a command with stdout/stderr/stdin attached
 func ExecCommand(name string, args ...string) error {
-	cmd := exec.Command(name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
+	return tool.Run(name, args...)
 }
 
 // gitCommit stages all changes and commits with a prefixed message
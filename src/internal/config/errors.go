@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds commands map to distinct exit codes. Match them with errors.Is.
+var (
+	ErrUnknownItem       = errors.New("unknown item")
+	ErrMissingDependency = errors.New("missing dependency")
+	ErrAborted           = errors.New("aborted")
+)
+
+// UnknownError reports a name matching no tool, package or item (e.g. "unknown tool: foo")
+func UnknownError(kind, name string) error {
+	return &kindError{msg: fmt.Sprintf("unknown %s: %s", kind, name), kind: ErrUnknownItem}
+}
+
+// MissingDependencyError reports a command or tool required by another one
+func MissingDependencyError(msg string) error {
+	return &kindError{msg: msg, kind: ErrMissingDependency}
+}
+
+// AbortedError reports an operation the user declined
+func AbortedError(msg string) error {
+	return &kindError{msg: msg, kind: ErrAborted}
+}
+
+// kindError keeps its own message while matching one of the error kinds
+type kindError struct {
+	msg  string
+	kind error
+}
+
+func (e *kindError) Error() string { return e.msg }
+
+func (e *kindError) Unwrap() error { return e.kind }
+
+// BatchError summarizes an operation run over several items, some of which failed
+type BatchError struct {
+	Failed int
+	Total  int
+	Errs   []error
+}
+
+// NewBatchError returns nil when no item failed, the error itself for a
+// single item, or a BatchError
+func NewBatchError(total int, errs []error) error {
+	switch {
+	case len(errs) == 0:
+		return nil
+	case total == 1:
+		return errs[0]
+	}
+	return &BatchError{Failed: len(errs), Total: total, Errs: errs}
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d failed", e.Failed, e.Total)
+}
+
+// Unwrap exposes the item errors only when every item failed: a batch where
+// some items succeeded is a partial failure, whatever the failures were
+func (e *BatchError) Unwrap() []error {
+	if e.Failed < e.Total {
+		return nil
+	}
+	return e.Errs
+}
+
+// Partial reports whether some items succeeded
+func (e *BatchError) Partial() bool {
+	return e.Failed < e.Total
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// GraphFormats are the formats RenderDependencyGraph writes
+var GraphFormats = []string{"dot", "mermaid"}
+
+// Node colors of installed and missing tools
+const (
+	graphInstalledFill   = "#c8e6c9"
+	graphInstalledStroke = "#2e7d32"
+	graphMissingFill     = "#ffcdd2"
+	graphMissingStroke   = "#c62828"
+)
+
+// RenderDependencyGraph renders tools (as GetToolsInDependencyOrder lists
+// them), their dependencies and their scripts as a DOT or Mermaid graph.
+// Edges point from a tool to what it needs. A non-nil installed colors each
+// tool by its installed state.
+func RenderDependencyGraph(tools []Tool, format string, installed map[string]bool) (string, error) {
+	switch format {
+	case "dot":
+		return renderDot(tools, installed), nil
+	case "mermaid":
+		return renderMermaid(tools, installed), nil
+	}
+	return "", fmt.Errorf("unknown graph format %q (use %s)", format, strings.Join(GraphFormats, " or "))
+}
+
+// graphScripts returns the scripts attached to tools, in order of first use
+func graphScripts(tools []Tool) []string {
+	var scripts []string
+	for _, t := range tools {
+		for _, s := range t.Scripts {
+			if !slices.Contains(scripts, s) {
+				scripts = append(scripts, s)
+			}
+		}
+	}
+	return scripts
+}
+
+func renderDot(tools []Tool, installed map[string]bool) string {
+	var b strings.Builder
+	b.WriteString("digraph tools {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=rounded];\n")
+
+	for _, t := range tools {
+		style := ""
+		if installed != nil {
+			fill, stroke := graphMissingFill, graphMissingStroke
+			if installed[t.Name] {
+				fill, stroke = graphInstalledFill, graphInstalledStroke
+			}
+			style = fmt.Sprintf(" [style=\"rounded,filled\", fillcolor=%q, color=%q]", fill, stroke)
+		}
+		fmt.Fprintf(&b, "\t%q%s;\n", t.Name, style)
+	}
+	for _, s := range graphScripts(tools) {
+		fmt.Fprintf(&b, "\t%q [label=%q, shape=note];\n", "script:"+s, s)
+	}
+
+	for _, t := range tools {
+		for _, dep := range t.Dependencies {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", t.Name, dep)
+		}
+		for _, s := range t.Scripts {
+			fmt.Fprintf(&b, "\t%q -> %q [style=dashed];\n", t.Name, "script:"+s)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// mermaidID turns a name into a Mermaid node id ("claude-agent-acp" -> "claude_agent_acp")
+func mermaidID(name string) string {
+	return mermaidUnsafe.ReplaceAllString(name, "_")
+}
+
+func renderMermaid(tools []Tool, installed map[string]bool) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, t := range tools {
+		fmt.Fprintf(&b, "    %s[\"%s\"]\n", mermaidID(t.Name), t.Name)
+	}
+	for _, s := range graphScripts(tools) {
+		fmt.Fprintf(&b, "    script_%s[/\"%s\"/]\n", mermaidID(s), s)
+	}
+
+	for _, t := range tools {
+		for _, dep := range t.Dependencies {
+			fmt.Fprintf(&b, "    %s --> %s\n", mermaidID(t.Name), mermaidID(dep))
+		}
+		for _, s := range t.Scripts {
+			fmt.Fprintf(&b, "    %s -.-> script_%s\n", mermaidID(t.Name), mermaidID(s))
+		}
+	}
+
+	if installed != nil {
+		var have, missing []string
+		for _, t := range tools {
+			if installed[t.Name] {
+				have = append(have, mermaidID(t.Name))
+			} else {
+				missing = append(missing, mermaidID(t.Name))
+			}
+		}
+		fmt.Fprintf(&b, "    classDef installed fill:%s,stroke:%s\n", graphInstalledFill, graphInstalledStroke)
+		fmt.Fprintf(&b, "    classDef missing fill:%s,stroke:%s\n", graphMissingFill, graphMissingStroke)
+		if len(have) > 0 {
+			fmt.Fprintf(&b, "    class %s installed\n", strings.Join(have, ","))
+		}
+		if len(missing) > 0 {
+			fmt.Fprintf(&b, "    class %s missing\n", strings.Join(missing, ","))
+		}
+	}
+	return b.String()
+}
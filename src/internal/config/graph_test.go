@@ -0,0 +1,70 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// graphTools is a small registry covering shared dependencies, shared scripts
+// and names Mermaid cannot use as ids
+func graphTools() []Tool {
+	return []Tool{
+		{Name: "homebrew", Method: InstallBrewFormula, Formula: "brew"},
+		{Name: "node", Method: InstallBrewFormula, Formula: "node", Dependencies: []string{"homebrew"}},
+		{Name: "claude-code", Method: InstallNpm, Formula: "@anthropic-ai/claude-code", Dependencies: []string{"node"}, Scripts: []string{"claude-config"}},
+		{Name: "gpg", Method: InstallBrewFormula, Formula: "gnupg", Dependencies: []string{"homebrew"}, Scripts: []string{"gpg"}},
+		{Name: "git", Method: InstallBrewFormula, Formula: "git", Dependencies: []string{"homebrew", "gpg"}, Scripts: []string{"gpg"}},
+	}
+}
+
+func TestRenderDependencyGraph(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = graphTools()
+
+	installed := map[string]bool{"homebrew": true, "node": true, "git": true}
+
+	tests := []struct {
+		golden    string
+		format    string
+		installed map[string]bool
+	}{
+		{"graph.dot", "dot", nil},
+		{"graph.mermaid", "mermaid", nil},
+		{"graph-installed.dot", "dot", installed},
+		{"graph-installed.mermaid", "mermaid", installed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := RenderDependencyGraph(GetToolsInDependencyOrder(), tt.format, tt.installed)
+			if err != nil {
+				t.Fatalf("RenderDependencyGraph() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *updateGolden {
+				if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run go test -update to create it)", err)
+			}
+			if got != string(expected) {
+				t.Errorf("RenderDependencyGraph() differs from %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestRenderDependencyGraphUnknownFormat(t *testing.T) {
+	if _, err := RenderDependencyGraph(graphTools(), "svg", nil); err == nil {
+		t.Fatal("RenderDependencyGraph(svg) error = nil, want unknown format")
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// HealthState is whether a service answers, beyond its binary being installed
+type HealthState string
+
+const (
+	HealthOK       HealthState = "healthy"
+	HealthDegraded HealthState = "degraded" // Answers, but cannot do its job (e.g. logged out)
+	HealthDown     HealthState = "down"     // Does not answer
+)
+
+// Health is the result of a Tool.HealthFn probe
+type Health struct {
+	State  HealthState
+	Reason string // Why the service is degraded or down, or what it reported
+}
+
+// Healthy creates a Health for a service that answers
+func Healthy(reason string) Health {
+	return Health{State: HealthOK, Reason: reason}
+}
+
+// Degraded creates a Health for a service that answers but is not usable
+func Degraded(reason string) Health {
+	return Health{State: HealthDegraded, Reason: reason}
+}
+
+// Down creates a Health for a service that does not answer
+func Down(reason string) Health {
+	return Health{State: HealthDown, Reason: reason}
+}
+
+// =============================================================================
+// Docker
+// =============================================================================
+
+// DockerSocket returns the Docker API socket: the unix:// $DOCKER_HOST, else
+// the first existing OrbStack or default socket
+func DockerSocket() string {
+	if host := os.Getenv("DOCKER_HOST"); strings.HasPrefix(host, "unix://") {
+		return strings.TrimPrefix(host, "unix://")
+	}
+	candidates := []string{
+		filepath.Join(os.Getenv("HOME"), ".orbstack", "run", "docker.sock"),
+		"/var/run/docker.sock",
+	}
+	for _, socket := range candidates {
+		if _, err := os.Stat(socket); err == nil {
+			return socket
+		}
+	}
+	return ""
+}
+
+// dockerHealth pings the Docker API on its socket
+func dockerHealth() Health {
+	socket := DockerSocket()
+	if socket == "" {
+		return Down("no docker socket")
+	}
+	return pingDocker(socket)
+}
+
+func pingDocker(socket string) Health {
+	status, body, err := tool.UnixHTTPGet(socket, "docker", "/_ping")
+	if err != nil {
+		return Down("docker socket not responding")
+	}
+	if status != http.StatusOK || strings.TrimSpace(string(body)) != "OK" {
+		return Degraded(fmt.Sprintf("docker ping returned %d", status))
+	}
+	return Healthy("")
+}
+
+// =============================================================================
+// Ollama
+// =============================================================================
+
+// OllamaURL returns the Ollama API base URL ($OLLAMA_HOST, else localhost:11434)
+func OllamaURL() string {
+	host := os.Getenv("OLLAMA_HOST")
+	if host == "" {
+		return "http://127.0.0.1:11434"
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	return strings.TrimSuffix(host, "/")
+}
+
+// ollamaHealth asks the Ollama API for its version
+func ollamaHealth() Health {
+	return pingOllama(OllamaURL())
+}
+
+func pingOllama(baseURL string) Health {
+	status, body, err := tool.HTTPGet(baseURL + "/api/version")
+	if err != nil {
+		return Down("ollama API not responding")
+	}
+	var version struct {
+		Version string `json:"version"`
+	}
+	if status != http.StatusOK || json.Unmarshal(body, &version) != nil || version.Version == "" {
+		return Degraded(fmt.Sprintf("ollama API returned %d", status))
+	}
+	return Healthy("api " + version.Version)
+}
+
+// =============================================================================
+// Tailscale
+// =============================================================================
+
+// tailscaledLocalAPIHost is the Host the tailscaled LocalAPI expects
+const tailscaledLocalAPIHost = "local-tailscaled.sock"
+
+// TailscaledSocket returns the first existing tailscaled LocalAPI socket: the
+// system daemon, then the userspace one j remote runs ("" when none exists,
+// e.g. with the macOS app, which has no socket)
+func TailscaledSocket() string {
+	candidates := []string{"/var/run/tailscale/tailscaled.sock", userspaceSocketPath()}
+	if CurrentPlatform().IsMac() {
+		candidates = []string{"/var/run/tailscaled.socket", userspaceSocketPath()}
+	}
+	for _, socket := range candidates {
+		if _, err := os.Stat(socket); err == nil {
+			return socket
+		}
+	}
+	return ""
+}
+
+// tailscaleHealth reads the backend state from the tailscaled LocalAPI, or
+// from `tailscale status --json` when there is no socket to ask
+func tailscaleHealth() Health {
+	if socket := TailscaledSocket(); socket != "" {
+		return tailscaledSocketHealth(socket)
+	}
+	out, err := tool.Output("tailscale", "status", "--json")
+	var st tailscaleStatus
+	if json.Unmarshal(out, &st) != nil || st.BackendState == "" {
+		if err != nil {
+			return Down("tailscaled not responding")
+		}
+		return Degraded("unreadable tailscale status")
+	}
+	return backendStateHealth(st.BackendState)
+}
+
+func tailscaledSocketHealth(socket string) Health {
+	status, body, err := tool.UnixHTTPGet(socket, tailscaledLocalAPIHost, "/localapi/v0/status")
+	if err != nil {
+		return Down("tailscaled socket not responding")
+	}
+	var st tailscaleStatus
+	if status != http.StatusOK || json.Unmarshal(body, &st) != nil {
+		return Degraded(fmt.Sprintf("tailscaled LocalAPI returned %d", status))
+	}
+	return backendStateHealth(st.BackendState)
+}
+
+// backendStateHealth maps a tailscale BackendState (Running, NeedsLogin, Stopped...)
+func backendStateHealth(state string) Health {
+	switch state {
+	case "Running":
+		return Healthy("")
+	case "NeedsLogin", "NeedsMachineAuth":
+		return Degraded("needs login (tailscale up)")
+	case "Stopped":
+		return Degraded("stopped (tailscale up)")
+	}
+	return Degraded(strings.ToLower(state))
+}
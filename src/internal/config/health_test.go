@@ -0,0 +1,74 @@
+package config
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// serveUnix serves handler on a unix socket and returns its path
+func serveUnix(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "j-health") // Socket paths are limited to ~100 bytes
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+	return socket
+}
+
+func TestHealthProbes(t *testing.T) {
+	respond := func(status int, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}
+	}
+	ollama := func(status int, body string) string {
+		server := httptest.NewServer(respond(status, body))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	stopped := httptest.NewServer(nil)
+	stopped.Close()
+
+	tests := []struct {
+		name     string
+		probe    func() Health
+		expected Health
+	}{
+		{"docker healthy", func() Health { return pingDocker(serveUnix(t, respond(200, "OK"))) }, Healthy("")},
+		{"docker error", func() Health { return pingDocker(serveUnix(t, respond(500, "boom"))) }, Degraded("docker ping returned 500")},
+		{"docker down", func() Health { return pingDocker(filepath.Join(t.TempDir(), "missing.sock")) }, Down("docker socket not responding")},
+		{"ollama healthy", func() Health { return pingOllama(ollama(200, `{"version":"0.5.7"}`)) }, Healthy("api 0.5.7")},
+		{"ollama error", func() Health { return pingOllama(ollama(503, "")) }, Degraded("ollama API returned 503")},
+		{"ollama down", func() Health { return pingOllama(stopped.URL) }, Down("ollama API not responding")},
+		{"tailscale running", func() Health {
+			return tailscaledSocketHealth(serveUnix(t, respond(200, `{"BackendState":"Running"}`)))
+		}, Healthy("")},
+		{"tailscale logged out", func() Health {
+			return tailscaledSocketHealth(serveUnix(t, respond(200, `{"BackendState":"NeedsLogin"}`)))
+		}, Degraded("needs login (tailscale up)")},
+		{"tailscale down", func() Health { return tailscaledSocketHealth(filepath.Join(t.TempDir(), "missing.sock")) }, Down("tailscaled socket not responding")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.probe(); got != tt.expected {
+				t.Errorf("probe = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
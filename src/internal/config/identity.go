@@ -2,8 +2,9 @@ package config
 
 import (
 	"os"
-	"os/exec"
 	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 )
 
 // IdentityCheck represents a developer identity verification
@@ -20,7 +21,7 @@ var IdentityChecks = []IdentityCheck{
 		Name:        "git-email",
 		Description: "Git commit email",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("git", "config", "--global", "user.email").Output()
+			out, _ := tool.Output("git", "config", "--global", "user.email")
 			email := strings.TrimSpace(string(out))
 			return CheckResult{Installed: email == UserEmail, Detail: email}
 		},
@@ -30,7 +31,7 @@ var IdentityChecks = []IdentityCheck{
 		Name:        "git-name",
 		Description: "Git commit author name",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("git", "config", "--global", "user.name").Output()
+			out, _ := tool.Output("git", "config", "--global", "user.name")
 			name := strings.TrimSpace(string(out))
 			return CheckResult{Installed: name != "", Detail: name}
 		},
@@ -40,7 +41,7 @@ var IdentityChecks = []IdentityCheck{
 		Name:        "git-signing",
 		Description: "Git commit signature",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("git", "config", "--global", "commit.gpgsign").Output()
+			out, _ := tool.Output("git", "config", "--global", "commit.gpgsign")
 			return CheckResult{Installed: strings.TrimSpace(string(out)) == "true"}
 		},
 		GoodWhen: true,
@@ -49,7 +50,7 @@ var IdentityChecks = []IdentityCheck{
 		Name:        "gpg-key",
 		Description: "GPG key for signing",
 		CheckFn: func() CheckResult {
-			out, err := exec.Command("gpg", "--list-secret-keys", "--keyid-format", "long").Output()
+			out, err := tool.Output("gpg", "--list-secret-keys", "--keyid-format", "long")
 			if err != nil || len(out) == 0 {
 				return NotInstalled()
 			}
@@ -61,10 +62,10 @@ var IdentityChecks = []IdentityCheck{
 		Name:        "github",
 		Description: "GitHub CLI authentication",
 		CheckFn: func() CheckResult {
-			if _, err := exec.LookPath("gh"); err != nil {
+			if _, err := tool.LookPath("gh"); err != nil {
 				return NotInstalled()
 			}
-			out, err := exec.Command("gh", "auth", "status").CombinedOutput()
+			out, err := tool.CombinedOutput("gh", "auth", "status")
 			if err != nil {
 				return NotInstalled()
 			}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// ToolInfo is everything j knows about one tool (j info)
+type ToolInfo struct {
+	Name         string           `json:"name"`
+	Aliases      []string         `json:"aliases,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	Category     string           `json:"category"`
+	Method       string           `json:"method"`
+	Formula      string           `json:"formula,omitempty"`
+	Binary       string           `json:"binary,omitempty"` // Resolved command path
+	App          string           `json:"app,omitempty"`    // App bundle path
+	Installed    bool             `json:"installed"`
+	Version      string           `json:"version,omitempty"`
+	MinVersion   string           `json:"min_version,omitempty"`
+	Dependencies []DependencyNode `json:"dependencies"`
+	Dependents   []DependencyNode `json:"dependents"` // Tools depending directly on this one
+	Scripts      []ScriptInfo     `json:"scripts"`
+	Upgrade      UpgradeRoute     `json:"upgrade"`
+	Source       SourceInfo       `json:"source"`
+}
+
+// DependencyNode is a tool in a dependency tree, with its own dependencies
+type DependencyNode struct {
+	Name         string           `json:"name"`
+	Installed    bool             `json:"installed"`
+	Dependencies []DependencyNode `json:"dependencies,omitempty"`
+}
+
+// ScriptInfo is a setup script attached to a tool, with its check state
+type ScriptInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state"` // "configured", "not configured", "run-once" or "unknown"
+}
+
+// UpgradeRoute is how j upgrades the tool
+type UpgradeRoute struct {
+	Command string `json:"command,omitempty"` // What j upgrade <tool> runs
+	Flag    string `json:"flag,omitempty"`    // The j upgrade flag upgrading its package manager
+	Note    string `json:"note,omitempty"`    // Why there is no route
+}
+
+// SourceInfo is the registry entry defining the tool
+type SourceInfo struct {
+	Registry string `json:"registry"`           // "built-in", "manifest" or "override"
+	Manifest string `json:"manifest,omitempty"` // User manifest path, for manifest tools and overrides
+}
+
+// GetToolInfo checks the tool, its dependency tree, its dependents and its
+// scripts. Unknown names get the error of FindTool.
+func GetToolInfo(name string) (ToolInfo, error) {
+	t, err := FindTool(name)
+	if err != nil {
+		return ToolInfo{}, err
+	}
+
+	var dependents []Tool
+	for _, other := range Tools {
+		if slices.Contains(other.Dependencies, t.Name) {
+			dependents = append(dependents, other)
+		}
+	}
+
+	// Check the tool, its dependency tree and its dependents once each, in parallel
+	checked := append([]Tool{*t}, dependents...)
+	seen := map[string]bool{t.Name: true}
+	for _, other := range dependents {
+		seen[other.Name] = true
+	}
+	checked = appendDependencies(checked, *t, seen)
+	results := map[string]CheckResult{}
+	for i, r := range CheckTools(checked) {
+		results[checked[i].Name] = r
+	}
+
+	result := results[t.Name]
+	info := ToolInfo{
+		Name:         t.Name,
+		Aliases:      t.Aliases,
+		Description:  t.Description,
+		Category:     string(t.Category),
+		Method:       t.Method.String(),
+		Formula:      t.Formula,
+		Binary:       t.BinaryPath(),
+		Installed:    result.Installed,
+		Version:      result.Version,
+		MinVersion:   t.MinVersion,
+		Dependencies: dependencyTree(*t, results, map[string]bool{t.Name: true}),
+		Dependents:   []DependencyNode{},
+		Scripts:      []ScriptInfo{},
+		Upgrade:      t.upgradeRoute(),
+		Source:       SourceInfo{Registry: "built-in"},
+	}
+	if t.App != "" {
+		info.App = filepath.Join("/Applications", t.App+".app")
+	}
+	if t.Source != SourceBuiltin {
+		info.Source = SourceInfo{Registry: string(t.Source), Manifest: ToolManifestPath()}
+	}
+
+	for _, other := range dependents {
+		info.Dependents = append(info.Dependents, DependencyNode{Name: other.Name, Installed: results[other.Name].Installed})
+	}
+
+	for _, name := range t.Scripts {
+		script := GetScriptByName(name)
+		if script == nil {
+			info.Scripts = append(info.Scripts, ScriptInfo{Name: name, State: "unknown"})
+			continue
+		}
+		state := "run-once"
+		if script.CheckFn != nil {
+			state = "not configured"
+			if script.CheckFn().Installed {
+				state = "configured"
+			}
+		}
+		info.Scripts = append(info.Scripts, ScriptInfo{Name: name, Description: script.Description, State: state})
+	}
+	return info, nil
+}
+
+// appendDependencies appends the tools of the dependency tree of t not seen yet
+func appendDependencies(tools []Tool, t Tool, seen map[string]bool) []Tool {
+	for _, name := range t.Dependencies {
+		dep := GetToolByName(name)
+		if dep == nil || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tools = appendDependencies(append(tools, *dep), *dep, seen)
+	}
+	return tools
+}
+
+// dependencyTree returns the dependencies of t, each with its own, installed
+// as in results. path holds the tools above in the tree, so a cycle stops
+// instead of recursing forever.
+func dependencyTree(t Tool, results map[string]CheckResult, path map[string]bool) []DependencyNode {
+	nodes := []DependencyNode{}
+	for _, name := range t.Dependencies {
+		node := DependencyNode{Name: name}
+		if dep := GetToolByName(name); dep != nil {
+			node.Installed = results[name].Installed
+			if !path[name] {
+				path[name] = true
+				node.Dependencies = dependencyTree(*dep, results, path)
+				delete(path, name)
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// BinaryPath returns the path the command of the tool resolves to ("" when
+// the tool has no command or it is not found)
+func (t Tool) BinaryPath() string {
+	if t.Command == "" {
+		if t.Method != InstallGo {
+			return ""
+		}
+		if _, err := os.Stat(t.goBinary()); err != nil {
+			return ""
+		}
+		return t.goBinary()
+	}
+	if path, err := tool.LookPath(t.Command); err == nil {
+		return path
+	}
+	if path, ok := t.releaseBinary(); ok {
+		return path
+	}
+	return ""
+}
+
+// upgradeRoute describes how j upgrade reaches the tool
+func (t Tool) upgradeRoute() UpgradeRoute {
+	args, err := t.UpgradeCommand()
+	if err != nil {
+		return UpgradeRoute{Note: err.Error()}
+	}
+	route := UpgradeRoute{Command: strings.Join(args, " ")}
+	flag := t.Method.String()
+	if t.Method == InstallBrewFormula || t.Method == InstallBrewCask {
+		flag = "brew" // brew upgrade covers casks too
+	}
+	if pm := GetPackageManagerByFlag(flag); pm != nil {
+		route.Flag = "--" + pm.Flag
+	}
+	return route
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestGetToolInfo(t *testing.T) {
+	fake := tool.NewFakeRunner().WithPath("brew", "jq")
+	t.Cleanup(tool.SetRunner(fake))
+
+	originalTools, originalScripts := Tools, Scripts
+	t.Cleanup(func() { Tools, Scripts = originalTools, originalScripts })
+	Tools = []Tool{
+		{Name: "homebrew", Command: "brew", Method: InstallManual, Category: CategoryPackageManager},
+		{Name: "jq", Command: "jq", Method: InstallBrewFormula, Formula: "jq", Category: CategoryTerminalGit, Dependencies: []string{"homebrew"}, Scripts: []string{"jq-config"}},
+		{Name: "fx", Command: "fx", Method: InstallBrewFormula, Formula: "fx", Category: CategoryTerminalGit, Dependencies: []string{"jq"}, Source: SourceManifest},
+	}
+	Scripts = []Script{{Name: "jq-config", Description: "Configure jq", CheckFn: func() CheckResult { return Installed() }}}
+
+	info, err := GetToolInfo("fx")
+	if err != nil {
+		t.Fatalf("GetToolInfo(fx) error = %v", err)
+	}
+	expectedTree := []DependencyNode{{Name: "jq", Installed: true, Dependencies: []DependencyNode{{Name: "homebrew", Installed: true, Dependencies: []DependencyNode{}}}}}
+	if !reflect.DeepEqual(info.Dependencies, expectedTree) {
+		t.Errorf("Dependencies = %+v, want %+v", info.Dependencies, expectedTree)
+	}
+	if info.Installed || info.Binary != "" || info.Source.Registry != "manifest" || info.Source.Manifest == "" {
+		t.Errorf("GetToolInfo(fx) = %+v, want a missing manifest tool", info)
+	}
+
+	info, err = GetToolInfo("jq")
+	if err != nil {
+		t.Fatalf("GetToolInfo(jq) error = %v", err)
+	}
+	if !info.Installed || info.Binary != "/usr/local/bin/jq" || info.Source.Registry != "built-in" {
+		t.Errorf("GetToolInfo(jq) = %+v, want an installed built-in tool", info)
+	}
+	if expected := []DependencyNode{{Name: "fx"}}; !reflect.DeepEqual(info.Dependents, expected) {
+		t.Errorf("Dependents = %+v, want %+v", info.Dependents, expected)
+	}
+	if expected := []ScriptInfo{{Name: "jq-config", Description: "Configure jq", State: "configured"}}; !reflect.DeepEqual(info.Scripts, expected) {
+		t.Errorf("Scripts = %+v, want %+v", info.Scripts, expected)
+	}
+	if expected := (UpgradeRoute{Command: "brew upgrade jq", Flag: "--brew"}); info.Upgrade != expected {
+		t.Errorf("Upgrade = %+v, want %+v", info.Upgrade, expected)
+	}
+
+	info, _ = GetToolInfo("homebrew")
+	if info.Upgrade.Command != "" || info.Upgrade.Note == "" {
+		t.Errorf("Upgrade of a manual tool = %+v, want a note only", info.Upgrade)
+	}
+
+	cask := Tool{Name: "zed", Method: InstallBrewCask, Formula: "zed"}
+	if expected := (UpgradeRoute{Command: "brew upgrade --cask zed", Flag: "--brew"}); cask.upgradeRoute() != expected {
+		t.Errorf("Upgrade of a cask = %+v, want %+v", cask.upgradeRoute(), expected)
+	}
+}
+
+func TestGetToolInfoChecksEachToolOnce(t *testing.T) {
+	originalTools := Tools
+	t.Cleanup(func() { Tools = originalTools })
+
+	var mu sync.Mutex
+	checks := map[string]int{}
+	checkFn := func(name string) func() CheckResult {
+		return func() CheckResult {
+			mu.Lock()
+			defer mu.Unlock()
+			checks[name]++
+			return Installed()
+		}
+	}
+	Tools = []Tool{
+		{Name: "base", Method: InstallManual, CheckFn: checkFn("base")},
+		{Name: "left", Method: InstallManual, Dependencies: []string{"base"}, CheckFn: checkFn("left")},
+		{Name: "right", Method: InstallManual, Dependencies: []string{"base"}, CheckFn: checkFn("right")},
+		{Name: "app", Method: InstallManual, Dependencies: []string{"left", "right"}, CheckFn: checkFn("app")},
+		{Name: "plugin", Method: InstallManual, Dependencies: []string{"app", "base"}, CheckFn: checkFn("plugin")},
+	}
+
+	if _, err := GetToolInfo("app"); err != nil {
+		t.Fatalf("GetToolInfo(app) error = %v", err)
+	}
+	if expected := map[string]int{"app": 1, "left": 1, "right": 1, "base": 1, "plugin": 1}; !reflect.DeepEqual(checks, expected) {
+		t.Errorf("checks = %v, want each tool checked once %v", checks, expected)
+	}
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// InstallStatus is the outcome of installing one tool
+type InstallStatus int
+
+const (
+	InstallSucceeded        InstallStatus = iota // Installed by this run
+	InstallAlreadyInstalled                      // Nothing to do
+	InstallFailed                                // Installer failed or no installer exists
+	InstallSkipped                               // A dependency was not installed
+)
+
+// String returns a display string for the install status
+func (s InstallStatus) String() string {
+	switch s {
+	case InstallSucceeded:
+		return "installed"
+	case InstallAlreadyInstalled:
+		return "already installed"
+	case InstallFailed:
+		return "failed"
+	case InstallSkipped:
+		return "skipped"
+	default:
+		return "-"
+	}
+}
+
+// InstallResult is the outcome of one tool in a parallel install
+type InstallResult struct {
+	Tool     Tool
+	Status   InstallStatus
+	Err      error
+	Output   string // Captured installer output (empty for interactive installers)
+	Duration time.Duration
+}
+
+// InstallOptions configures InstallTools
+type InstallOptions struct {
+	Jobs    int                 // Maximum concurrent installs (< 1 = 1)
+	OnStart func(t Tool)        // Called when a tool starts installing (optional)
+	OnDone  func(InstallResult) // Called when a tool is settled (optional)
+}
+
+// InstallTools installs tools concurrently, each one starting as soon as its
+// Dependencies are installed. Dependencies missing from tools are only checked.
+// Tools must come in dependency order: a tool on a dependency cycle fails and
+// a tool whose dependency is listed after it is skipped, so nothing deadlocks.
+//
+// Brew invocations are serialized because brew holds a global lock, and so are
+// apt, dnf and pacman, which lock their package database. Custom installers
+// (InstallFn and installer scripts, usually interactive) and sudo installs
+// (which may ask for a password) run alone with the terminal attached. Other
+// installers run with their output captured in InstallResult.Output. Results
+// keep the order of tools.
+func InstallTools(tools []Tool, opts InstallOptions) []InstallResult {
+	jobs := opts.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	index := make(map[string]int, len(tools))
+	toolsByName := make(map[string]Tool, len(tools))
+	for i, t := range tools {
+		index[t.Name] = i
+		toolsByName[t.Name] = t
+	}
+
+	results := make([]InstallResult, len(tools))
+	done := make([]chan struct{}, len(tools))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var (
+		slots    = make(chan struct{}, jobs)
+		locks    installLocks
+		eventsMu sync.Mutex
+		wg       sync.WaitGroup
+	)
+
+	settle := func(i int, r InstallResult) {
+		results[i] = r
+		if opts.OnDone != nil {
+			eventsMu.Lock()
+			opts.OnDone(r)
+			eventsMu.Unlock()
+		}
+		close(done[i])
+	}
+
+	onCycle := make(map[int]error)
+	for _, cycle := range findDependencyCycles(tools, toolsByName) {
+		err := fmt.Errorf("dependency cycle %s", strings.Join(cycle, " -> "))
+		for _, name := range cycle[:len(cycle)-1] {
+			if _, seen := onCycle[index[name]]; !seen {
+				onCycle[index[name]] = err
+			}
+		}
+	}
+	for i, t := range tools {
+		if err, ok := onCycle[i]; ok {
+			settle(i, InstallResult{Tool: t, Status: InstallFailed, Err: err})
+		}
+	}
+
+	for i, t := range tools {
+		if _, ok := onCycle[i]; ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, t Tool) {
+			defer wg.Done()
+
+			for _, dep := range t.Dependencies {
+				if reason := waitForDependency(i, dep, index, done, results); reason != "" {
+					settle(i, InstallResult{Tool: t, Status: InstallSkipped, Err: fmt.Errorf("%s", reason)})
+					return
+				}
+			}
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			if t.Check().Installed {
+				settle(i, InstallResult{Tool: t, Status: InstallAlreadyInstalled})
+				return
+			}
+
+			if opts.OnStart != nil {
+				eventsMu.Lock()
+				opts.OnStart(t)
+				eventsMu.Unlock()
+			}
+
+			start := time.Now()
+			output, err := installLocked(t, &locks)
+			r := InstallResult{Tool: t, Status: InstallSucceeded, Err: err, Output: output, Duration: time.Since(start)}
+			if err != nil {
+				r.Status = InstallFailed
+			}
+			settle(i, r)
+		}(i, t)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// waitForDependency blocks until dep is settled and returns why the tool at
+// position i cannot be installed, or "" when dep is available. Only tools
+// ordered before i are waited on, so a misordered list cannot deadlock.
+func waitForDependency(i int, dep string, index map[string]int, done []chan struct{}, results []InstallResult) string {
+	j, ok := index[dep]
+	if !ok {
+		// Not part of this run: it must already be there
+		if t := GetToolByName(dep); t != nil && !t.Check().Installed {
+			return dep + " is not installed"
+		}
+		return ""
+	}
+	if j >= i {
+		return dep + " is not ordered before it"
+	}
+
+	<-done[j]
+	switch results[j].Status {
+	case InstallSucceeded, InstallAlreadyInstalled:
+		return ""
+	default:
+		return dep + " was not installed"
+	}
+}
+
+// installLocks are the locks installers take in a parallel install
+type installLocks struct {
+	brew     sync.Mutex   // brew refuses concurrent invocations
+	system   sync.Mutex   // apt, dnf and pacman lock their package database
+	terminal sync.RWMutex // Interactive installers take it exclusively
+}
+
+// installLocked runs the tool installer while holding the locks it needs
+func installLocked(t Tool, locks *installLocks) (string, error) {
+	terminal := &locks.terminal
+	if t.InstallFn != nil || t.RemoteScript != nil {
+		terminal.Lock()
+		defer terminal.Unlock()
+		return "", t.Install()
+	}
+
+	if t.Method == InstallRelease {
+		terminal.RLock()
+		defer terminal.RUnlock()
+		return "", t.installRelease()
+	}
+
+	args, err := t.installCommand()
+	if err != nil {
+		return "", err
+	}
+
+	switch t.Method {
+	case InstallBrewFormula, InstallBrewCask:
+		locks.brew.Lock()
+		defer locks.brew.Unlock()
+		defer tool.ResetBrewInventory() // Later checks must see the new package
+	case InstallApt, InstallDnf, InstallPacman:
+		locks.system.Lock()
+		defer locks.system.Unlock()
+	}
+
+	if args[0] == "sudo" {
+		// sudo may prompt for a password: run in the foreground with the terminal
+		terminal.Lock()
+		defer terminal.Unlock()
+		return "", tool.RunLogged(args[0], args[1:]...)
+	}
+
+	terminal.RLock()
+	defer terminal.RUnlock()
+
+	var output bytes.Buffer
+	err = tool.RunCmd(tool.Cmd{Name: args[0], Args: args[1:], Stdout: &output, Stderr: &output, Logged: true})
+	return output.String(), err
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// trackingRunner records the peak number of concurrent invocations of one command
+type trackingRunner struct {
+	*tool.FakeRunner
+	name      string
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (r *trackingRunner) Run(c tool.Cmd) error {
+	if c.Name == r.name {
+		r.mu.Lock()
+		r.active++
+		if r.active > r.maxActive {
+			r.maxActive = r.active
+		}
+		r.mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		r.mu.Lock()
+		r.active--
+		r.mu.Unlock()
+	}
+	return r.FakeRunner.Run(c)
+}
+
+func TestInstallTools(t *testing.T) {
+	useAppleSilicon(t)
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "homebrew", Command: "brew", Method: InstallManual},
+		{Name: "bun", Command: "bun", Method: InstallBrewFormula, Formula: "bun", Dependencies: []string{"homebrew"}},
+		{Name: "go", Command: "go", Method: InstallBrewFormula, Formula: "go", Dependencies: []string{"homebrew"}},
+		{Name: "zed", Command: "zed", Method: InstallBrewCask, Formula: "zed", Dependencies: []string{"homebrew"}},
+		{Name: "broken", Command: "broken", Method: InstallBrewFormula, Formula: "broken", Dependencies: []string{"homebrew"}},
+		{Name: "codex", Command: "codex", Method: InstallBun, Formula: "@openai/codex", Dependencies: []string{"bun"}},
+		{Name: "needs-broken", Command: "nb", Method: InstallBun, Formula: "nb", Dependencies: []string{"broken"}},
+	}
+
+	fake := tool.NewFakeRunner().
+		WithPath("brew").
+		On("arch -arm64 brew install broken", tool.FakeResponse{Stderr: "No formula", Err: errors.New("exit status 1")})
+	runner := &trackingRunner{FakeRunner: fake, name: "arch"}
+	t.Cleanup(tool.SetRunner(runner))
+
+	results := InstallTools(GetToolsInDependencyOrder(), InstallOptions{Jobs: 4})
+
+	expected := map[string]InstallStatus{
+		"bun":          InstallSucceeded,
+		"go":           InstallSucceeded,
+		"zed":          InstallSucceeded,
+		"broken":       InstallFailed,
+		"codex":        InstallSucceeded,
+		"needs-broken": InstallSkipped,
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("got %d results, want %d", len(results), len(expected))
+	}
+	for _, r := range results {
+		if r.Status != expected[r.Tool.Name] {
+			t.Errorf("%s: status = %s, want %s (err: %v)", r.Tool.Name, r.Status, expected[r.Tool.Name], r.Err)
+		}
+		if r.Tool.Name == "broken" && r.Output != "No formula" {
+			t.Errorf("broken: output = %q, want the captured stderr", r.Output)
+		}
+	}
+
+	if runner.maxActive != 1 {
+		t.Errorf("brew ran %d times concurrently, want 1", runner.maxActive)
+	}
+
+	position := make(map[string]int)
+	for i, c := range fake.Commands() {
+		position[c] = i
+	}
+	if position["bun install -g @openai/codex"] < position["arch -arm64 brew install bun"] {
+		t.Error("codex was installed before its bun dependency")
+	}
+	if _, ran := position["bun install -g nb"]; ran {
+		t.Error("needs-broken should not install when its dependency failed")
+	}
+}
+
+func TestInstallToolsResetsBrewInventory(t *testing.T) {
+	useAppleSilicon(t)
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{{Name: "jq", Method: InstallBrewFormula, Formula: "jq"}}
+
+	const brewInfo = "brew info --json=v2 --installed"
+	fake := tool.NewFakeRunner().WithPath("brew").
+		On(brewInfo, tool.FakeResponse{Stdout: `{"formulae": [], "casks": []}`})
+	t.Cleanup(tool.SetRunner(fake))
+
+	results := InstallTools(Tools, InstallOptions{Jobs: 1})
+	if results[0].Status != InstallSucceeded {
+		t.Fatalf("status = %s, want installed (err: %v)", results[0].Status, results[0].Err)
+	}
+	Tools[0].Check()
+
+	loads := 0
+	for _, c := range fake.Commands() {
+		if c == brewInfo {
+			loads++
+		}
+	}
+	if loads != 2 {
+		t.Errorf("brew info ran %d times, want 2 (before and after the install): %v", loads, fake.Commands())
+	}
+}
+
+func TestInstallToolsLinuxPackageManagers(t *testing.T) {
+	t.Cleanup(SetPlatform(Platform{OS: "linux", Arch: "amd64", SystemPackageManager: InstallApt}))
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "jq", Command: "jq", Method: InstallApt, Formula: "jq"},
+		{Name: "git", Command: "git", Method: InstallApt, Formula: "git"},
+		{Name: "tmux", Command: "tmux", Method: InstallApt, Formula: "tmux"},
+	}
+
+	runner := &trackingRunner{FakeRunner: tool.NewFakeRunner(), name: "sudo"}
+	t.Cleanup(tool.SetRunner(runner))
+
+	for _, r := range InstallTools(Tools, InstallOptions{Jobs: 3}) {
+		if r.Status != InstallSucceeded {
+			t.Errorf("%s: status = %s, want installed (err: %v)", r.Tool.Name, r.Status, r.Err)
+		}
+	}
+	if runner.maxActive != 1 {
+		t.Errorf("apt-get ran %d times concurrently, want 1", runner.maxActive)
+	}
+	for _, c := range runner.Calls() {
+		if c.Name == "sudo" && c.Stdin != os.Stdin {
+			t.Errorf("%s: sudo needs the terminal to ask for a password", c)
+		}
+	}
+}
+
+func TestInstallToolsMissingExternalDependency(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "homebrew", Command: "brew", Method: InstallManual},
+		{Name: "go", Command: "go", Method: InstallBrewFormula, Formula: "go", Dependencies: []string{"homebrew"}},
+	}
+
+	fake := tool.NewFakeRunner()
+	t.Cleanup(tool.SetRunner(fake))
+
+	results := InstallTools([]Tool{Tools[1]}, InstallOptions{Jobs: 2})
+	if results[0].Status != InstallSkipped {
+		t.Fatalf("status = %s, want skipped", results[0].Status)
+	}
+	if len(fake.Commands()) != 0 {
+		t.Errorf("nothing should run, got %v", fake.Commands())
+	}
+}
+
+func TestInstallToolsDependencyCycle(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "aa", Command: "aa", Method: InstallBun, Formula: "aa", Dependencies: []string{"bb"}},
+		{Name: "bb", Command: "bb", Method: InstallBun, Formula: "bb", Dependencies: []string{"aa"}},
+		{Name: "cc", Command: "cc", Method: InstallBun, Formula: "cc", Dependencies: []string{"aa"}},
+		{Name: "late", Command: "late", Method: InstallBun, Formula: "late", Dependencies: []string{"dd"}},
+		{Name: "dd", Command: "dd", Method: InstallBun, Formula: "dd"},
+	}
+
+	fake := tool.NewFakeRunner()
+	t.Cleanup(tool.SetRunner(fake))
+
+	finished := make(chan []InstallResult)
+	go func() { finished <- InstallTools(Tools, InstallOptions{Jobs: 2}) }()
+
+	var results []InstallResult
+	select {
+	case results = <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("InstallTools deadlocked on a dependency cycle")
+	}
+
+	expected := map[string]InstallStatus{
+		"aa":   InstallFailed,
+		"bb":   InstallFailed,
+		"cc":   InstallSkipped,
+		"late": InstallSkipped,
+		"dd":   InstallSucceeded,
+	}
+	for _, r := range results {
+		if r.Status != expected[r.Tool.Name] {
+			t.Errorf("%s: status = %s, want %s (err: %v)", r.Tool.Name, r.Status, expected[r.Tool.Name], r.Err)
+		}
+	}
+	if got := fake.Commands(); len(got) != 1 || got[0] != "bun install -g dd" {
+		t.Errorf("commands = %v, want only dd installed", got)
+	}
+}
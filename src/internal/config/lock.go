@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// LockfileName is the default lockfile written by `j lock`, in the current directory
+const LockfileName = "j.lock.json"
+
+// lockfileVersion is bumped on incompatible format changes
+const lockfileVersion = 1
+
+// Lockfile snapshots the installed tools so machines can be reproduced with `j install --frozen`
+type Lockfile struct {
+	Version  int          `json:"version"`
+	Platform string       `json:"platform"` // e.g. "darwin/arm64"
+	Tools    []LockedTool `json:"tools"`
+}
+
+// LockedTool is one installed tool in the lockfile
+type LockedTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Method  string `json:"method"`
+	Formula string `json:"formula,omitempty"`
+}
+
+// BuildLockfile checks tools in parallel and records the installed ones in registry order
+func BuildLockfile(tools []Tool) Lockfile {
+	results := CheckTools(tools)
+
+	p := CurrentPlatform()
+	lock := Lockfile{Version: lockfileVersion, Platform: p.OS + "/" + p.Arch, Tools: []LockedTool{}}
+	for i, t := range tools {
+		if !results[i].Installed {
+			continue
+		}
+		lock.Tools = append(lock.Tools, LockedTool{
+			Name:    t.Name,
+			Version: results[i].Version,
+			Method:  string(t.Method),
+			Formula: t.Formula,
+		})
+	}
+	return lock
+}
+
+// WriteLockfile writes the lockfile as indented JSON
+func WriteLockfile(path string, lock Lockfile) error {
+	out, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	out = append(out, '\n')
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadLockfile reads a lockfile written by WriteLockfile
+func ReadLockfile(path string) (Lockfile, error) {
+	var lock Lockfile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lock, fmt.Errorf("%s not found. Run: j lock", path)
+		}
+		return lock, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return lock, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if lock.Version != lockfileVersion {
+		return lock, fmt.Errorf("%s has unsupported version %d (expected %d)", path, lock.Version, lockfileVersion)
+	}
+	return lock, nil
+}
+
+// InstallPinned installs the tool at the locked version when its backend can
+// pin one. Otherwise the latest version is installed and the returned warning
+// explains why the version could not be pinned.
+func (t Tool) InstallPinned(version string) (warning string, err error) {
+	if version == "" {
+		return "no version locked, installing latest", t.Install()
+	}
+
+	args, warning := t.pinnedInstallCommand(version)
+	if args == nil {
+		return warning, t.Install()
+	}
+	defer tool.ResetBrewInventory()
+	return warning, tool.RunLogged(args[0], args[1:]...)
+}
+
+// pinnedInstallCommand returns the command installing version, or nil and the
+// reason the backend cannot pin it. The warning may be set with a command when
+// the pin is only approximate.
+func (t Tool) pinnedInstallCommand(version string) ([]string, string) {
+	if t.InstallFn != nil || t.RemoteScript != nil {
+		return nil, "custom installer cannot pin versions, installing latest"
+	}
+
+	switch t.Method {
+	case InstallNpm:
+		return []string{"npm", "install", "-g", t.Formula + "@" + version}, ""
+	case InstallBun:
+		return []string{"bun", "add", "-g", t.Formula + "@" + version}, ""
+	case InstallCargo:
+		return []string{"cargo", "install", "--locked", t.Formula, "--version", version}, ""
+	case InstallUv:
+		return []string{"uv", "tool", "install", t.Formula + "==" + version}, ""
+	case InstallPipx:
+		return []string{"pipx", "install", t.Formula + "==" + version}, ""
+	case InstallGo:
+		return []string{"go", "install", t.goPackage() + "@v" + strings.TrimPrefix(version, "v")}, ""
+	case InstallBrewFormula:
+		// Homebrew only ships versioned formulae for some series (python@3.12, openjdk@21)
+		for _, series := range brewSeries(version) {
+			formula := t.Formula + "@" + series
+			if tool.RunSilent("brew", "info", formula) == nil {
+				warning := ""
+				if series != version {
+					warning = fmt.Sprintf("pinned to the %s series, brew cannot pin %s exactly", formula, version)
+				}
+				return brewCommand("install", formula), warning
+			}
+		}
+		return nil, fmt.Sprintf("brew has no versioned formula for %s %s, installing latest", t.Formula, version)
+	default:
+		return nil, fmt.Sprintf("%s cannot pin versions, installing latest", t.Method.String())
+	}
+}
+
+// brewSeries returns the versioned formula suffixes to try, most specific first
+// (e.g. "3.12.1" -> ["3.12", "3"])
+func brewSeries(version string) []string {
+	parts := strings.Split(version, ".")
+	var series []string
+	for n := min(len(parts), 2); n >= 1; n-- {
+		series = append(series, strings.Join(parts[:n], "."))
+	}
+	return series
+}
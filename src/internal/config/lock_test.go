@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestToolInstallPinned(t *testing.T) {
+	useAppleSilicon(t)
+	missing := tool.FakeResponse{Err: errors.New("No available formula")}
+	tests := []struct {
+		name            string
+		given           Tool
+		version         string
+		expected        string
+		expectedWarning bool
+	}{
+		{"npm", Tool{Name: "eas", Method: InstallNpm, Formula: "eas-cli"}, "16.3.1", "npm install -g eas-cli@16.3.1", false},
+		{"bun", Tool{Name: "codex", Method: InstallBun, Formula: "@openai/codex"}, "0.1.2", "bun add -g @openai/codex@0.1.2", false},
+		{"cargo", Tool{Name: "ripgrep", Method: InstallCargo, Formula: "ripgrep"}, "14.1.0", "cargo install --locked ripgrep --version 14.1.0", false},
+		{"uv", Tool{Name: "ruff", Method: InstallUv, Formula: "ruff"}, "0.6.9", "uv tool install ruff==0.6.9", false},
+		{"go", Tool{Name: "gopls", Method: InstallGo, Formula: "golang.org/x/tools/gopls"}, "0.16.2", "go install golang.org/x/tools/gopls@v0.16.2", false},
+		{"brew series", Tool{Name: "python", Method: InstallBrewFormula, Formula: "python"}, "3.12.1", "arch -arm64 brew install python@3.12", true},
+		{"brew major", Tool{Name: "openjdk", Method: InstallBrewFormula, Formula: "openjdk"}, "21.0.2", "arch -arm64 brew install openjdk@21", true},
+		{"brew unpinnable", Tool{Name: "go", Method: InstallBrewFormula, Formula: "go"}, "1.24.1", "arch -arm64 brew install go", true},
+		{"cask", Tool{Name: "zed", Method: InstallBrewCask, Formula: "zed"}, "0.180.0", "arch -arm64 brew install --cask zed", true},
+		{"no version", Tool{Name: "eas", Method: InstallNpm, Formula: "eas-cli"}, "", "npm install -g eas-cli", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := tool.NewFakeRunner().
+				On("brew info python@3.12", tool.FakeResponse{}).
+				On("brew info openjdk@21.0", missing).
+				On("brew info openjdk@21", tool.FakeResponse{}).
+				On("brew info go@1.24", missing).
+				On("brew info go@1", missing)
+			t.Cleanup(tool.SetRunner(fake))
+
+			warning, err := tt.given.InstallPinned(tt.version)
+			if err != nil {
+				t.Fatalf("InstallPinned() error = %v", err)
+			}
+			if (warning != "") != tt.expectedWarning {
+				t.Errorf("InstallPinned() warning = %q, want warning %v", warning, tt.expectedWarning)
+			}
+			var installs []string
+			for _, c := range fake.Commands() {
+				if !strings.Contains(c, "brew info") {
+					installs = append(installs, c)
+				}
+			}
+			if len(installs) != 1 || installs[0] != tt.expected {
+				t.Errorf("InstallPinned() ran %v, want [%s]", installs, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLockfileRoundtrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockfileName)
+	given := Lockfile{
+		Version:  lockfileVersion,
+		Platform: "darwin/arm64",
+		Tools: []LockedTool{
+			{Name: "go", Version: "1.24.1", Method: string(InstallBrewFormula), Formula: "go"},
+			{Name: "eas", Version: "16.3.1", Method: string(InstallNpm), Formula: "eas-cli"},
+		},
+	}
+
+	if err := WriteLockfile(path, given); err != nil {
+		t.Fatalf("WriteLockfile() error = %v", err)
+	}
+	got, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatalf("ReadLockfile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, given) {
+		t.Errorf("ReadLockfile() = %+v, want %+v", got, given)
+	}
+
+	if _, err := ReadLockfile(filepath.Join(t.TempDir(), "missing.json")); err == nil || !strings.Contains(err.Error(), "j lock") {
+		t.Errorf("ReadLockfile() on a missing file error = %v, want a hint to run j lock", err)
+	}
+}
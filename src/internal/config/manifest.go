@@ -0,0 +1,489 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToolManifest is the user-editable tool registry extension.
+// It lives in ~/.config/jterrazz/tools.yaml (or tools.yml / tools.json)
+// and is merged into Tools at startup.
+type ToolManifest struct {
+	Tools []ToolManifestEntry `json:"tools" yaml:"tools"`
+}
+
+// ToolManifestEntry declares a new tool or overrides fields of a built-in one.
+// Entries whose name matches a built-in tool only replace the fields they set.
+type ToolManifestEntry struct {
+	Name         string   `json:"name" yaml:"name"`
+	Description  string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Category     string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Method       string   `json:"method,omitempty" yaml:"method,omitempty"`
+	Formula      string   `json:"formula,omitempty" yaml:"formula,omitempty"`
+	AppStoreID   int      `json:"app_store_id,omitempty" yaml:"app_store_id,omitempty"`
+	Command      string   `json:"command,omitempty" yaml:"command,omitempty"`
+	MinVersion   string   `json:"min_version,omitempty" yaml:"min_version,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Scripts      []string `json:"scripts,omitempty" yaml:"scripts,omitempty"`
+
+	// Linux maps apt, dnf or pacman to the package name on that package manager
+	Linux map[string]string `json:"linux,omitempty" yaml:"linux,omitempty"`
+}
+
+// ToolSource is the registry entry a tool comes from
+type ToolSource string
+
+const (
+	SourceBuiltin  ToolSource = ""         // The built-in registry
+	SourceManifest ToolSource = "manifest" // Declared in the user manifest
+	SourceOverride ToolSource = "override" // Built-in, with fields set by the user manifest
+)
+
+// manifestMethods are the install methods a manifest entry may declare
+var manifestMethods = []InstallMethod{InstallBrewFormula, InstallBrewCask, InstallNpm, InstallBun, InstallMAS, InstallCargo, InstallUv, InstallPipx, InstallGo}
+
+// linuxMethods are the package managers a Linux mapping may target
+var linuxMethods = []InstallMethod{InstallApt, InstallDnf, InstallPacman}
+
+func manifestDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".config", "jterrazz")
+}
+
+// ToolManifestPath returns the path of the user tool manifest.
+// The first existing file among tools.yaml, tools.yml and tools.json wins;
+// tools.yaml is returned when none exists.
+func ToolManifestPath() string {
+	for _, name := range []string{"tools.yaml", "tools.yml", "tools.json"} {
+		path := filepath.Join(manifestDir(), name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(manifestDir(), "tools.yaml")
+}
+
+// LoadToolManifest reads the user tool manifest. A missing file returns an empty manifest.
+func LoadToolManifest() (ToolManifest, error) {
+	path := ToolManifestPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ToolManifest{}, nil
+		}
+		return ToolManifest{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return ParseToolManifest(path, data)
+}
+
+// ParseToolManifest decodes a manifest, choosing JSON or YAML from the file extension.
+// Unknown keys are rejected so typos surface instead of being silently ignored.
+func ParseToolManifest(path string, data []byte) (ToolManifest, error) {
+	var manifest ToolManifest
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&manifest); err != nil {
+			return manifest, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return manifest, nil
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&manifest); err != nil && !errors.Is(err, io.EOF) {
+		return manifest, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// ValidateToolManifest checks manifest entries against the built-in registry,
+// including dependency cycles the merged registry would contain.
+// All problems are reported together, each prefixed with the offending entry.
+func ValidateToolManifest(manifest ToolManifest, builtin []Tool, scripts []Script) error {
+	known := make(map[string]bool, len(builtin)+len(manifest.Tools))
+	for _, t := range builtin {
+		known[t.Name] = true
+	}
+	for _, e := range manifest.Tools {
+		if e.Name != "" {
+			known[e.Name] = true
+		}
+	}
+
+	scriptNames := make(map[string]bool, len(scripts))
+	for _, s := range scripts {
+		scriptNames[s.Name] = true
+	}
+
+	var errs []error
+	seen := make(map[string]bool, len(manifest.Tools))
+	for i, e := range manifest.Tools {
+		label := fmt.Sprintf("tools[%d]", i)
+		if e.Name != "" {
+			label = fmt.Sprintf("tools[%d] (%q)", i, e.Name)
+		}
+		fail := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%s: %s", label, fmt.Sprintf(format, args...)))
+		}
+
+		if strings.TrimSpace(e.Name) == "" {
+			fail("name is required")
+			continue
+		}
+		if seen[e.Name] {
+			fail("duplicate entry")
+		}
+		seen[e.Name] = true
+
+		override := false
+		method := InstallMethod(e.Method)
+		for _, t := range builtin {
+			if t.Name == e.Name {
+				override = true
+				if method == "" {
+					method = t.Method
+				}
+				break
+			}
+		}
+
+		if e.Method != "" && !isManifestMethod(InstallMethod(e.Method)) {
+			fail("unknown method %q (expected one of %s)", e.Method, joinMethods(manifestMethods))
+		}
+		if !override && e.Method == "" {
+			fail("method is required for new tools (one of %s)", joinMethods(manifestMethods))
+		}
+		if e.AppStoreID != 0 && method != InstallMAS {
+			fail("app_store_id is only used by the mas method")
+		}
+		if !override && method == InstallMAS && e.AppStoreID == 0 {
+			fail("app_store_id is required for new mas tools")
+		}
+		if e.MinVersion != "" {
+			if _, err := (Tool{MinVersion: e.MinVersion}).VersionConstraint(); err != nil {
+				fail("min_version: %v", err)
+			}
+		}
+		if e.Category != "" {
+			if _, ok := lookupCategory(e.Category); !ok {
+				fail("unknown category %q (expected one of %s)", e.Category, joinCategories(ToolCategories))
+			}
+		} else if !override {
+			fail("category is required for new tools (one of %s)", joinCategories(ToolCategories))
+		}
+		for _, dep := range e.Dependencies {
+			if dep == e.Name {
+				fail("depends on itself")
+			} else if !known[dep] {
+				fail("unknown dependency %q", dep)
+			}
+		}
+		for _, script := range e.Scripts {
+			if !scriptNames[script] {
+				fail("unknown script %q", script)
+			}
+		}
+		for pm := range e.Linux {
+			if !isLinuxMethod(InstallMethod(pm)) {
+				fail("unknown linux package manager %q (expected one of %s)", pm, joinMethods(linuxMethods))
+			}
+		}
+	}
+
+	merged := MergeTools(builtin, manifest)
+	mergedByName := make(map[string]Tool, len(merged))
+	for _, t := range merged {
+		mergedByName[t.Name] = t
+	}
+	for _, cycle := range findDependencyCycles(merged, mergedByName) {
+		errs = append(errs, fmt.Errorf("dependency cycle %s", strings.Join(cycle, " -> ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// MergeTools returns builtin extended with the manifest entries.
+// Overrides keep their registry position; new tools are appended in manifest order.
+func MergeTools(builtin []Tool, manifest ToolManifest) []Tool {
+	merged := make([]Tool, len(builtin))
+	copy(merged, builtin)
+
+	index := make(map[string]int, len(merged))
+	for i, t := range merged {
+		index[t.Name] = i
+	}
+
+	for _, e := range manifest.Tools {
+		if i, ok := index[e.Name]; ok {
+			merged[i] = e.apply(merged[i])
+			if merged[i].Source == SourceBuiltin {
+				merged[i].Source = SourceOverride
+			}
+			continue
+		}
+		t := e.apply(Tool{Name: e.Name})
+		t.Source = SourceManifest
+		if t.Formula == "" {
+			t.Formula = t.Name
+		}
+		if t.Dependencies == nil {
+			t.Dependencies = defaultDependencies(t.Method)
+		}
+		index[e.Name] = len(merged)
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+// apply overlays the fields set on the entry onto t
+func (e ToolManifestEntry) apply(t Tool) Tool {
+	if e.Description != "" {
+		t.Description = e.Description
+	}
+	if e.Category != "" {
+		t.Category, _ = lookupCategory(e.Category)
+	}
+	if e.Method != "" && InstallMethod(e.Method) != t.Method {
+		t.Method = InstallMethod(e.Method)
+		t.InstallFn = nil // The built-in installers no longer match the method
+		t.UninstallFn = nil
+		t.RemoteScript = nil
+	}
+	if e.Formula != "" && e.Formula != t.Formula {
+		t.Formula = e.Formula
+		t.InstallFn = nil
+		t.UninstallFn = nil
+		t.RemoteScript = nil
+	}
+	if e.AppStoreID != 0 {
+		t.AppStoreID = e.AppStoreID
+	}
+	if e.Command != "" && e.Command != t.Command {
+		// Custom checks and version lookups target the old binary
+		t.Command = e.Command
+		t.CheckFn = nil
+		t.VersionFn = nil
+	}
+	if e.MinVersion != "" {
+		t.MinVersion = e.MinVersion
+	}
+	if len(e.Dependencies) > 0 {
+		t.Dependencies = e.Dependencies
+	}
+	if len(e.Scripts) > 0 {
+		t.Scripts = e.Scripts
+	}
+	if len(e.Linux) > 0 {
+		t.Linux = make(map[InstallMethod]string, len(e.Linux))
+		for pm, pkg := range e.Linux {
+			t.Linux[InstallMethod(pm)] = pkg
+		}
+	}
+	return t
+}
+
+// SaveToolManifest writes the manifest to path, as JSON or YAML from the file extension
+func SaveToolManifest(path string, manifest ToolManifest) error {
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = json.MarshalIndent(manifest, "", "  ")
+		data = append(data, '\n')
+	} else {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err = enc.Encode(manifest)
+		data = buf.Bytes()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// AppendToolManifest adds entries to the manifest at path. YAML manifests are
+// edited in place so the comments and key order of a hand-edited file survive;
+// JSON manifests (which hold no comments) are rewritten.
+func AppendToolManifest(path string, entries []ToolManifestEntry) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var manifest ToolManifest
+		if len(data) > 0 {
+			if manifest, err = ParseToolManifest(path, data); err != nil {
+				return err
+			}
+		}
+		manifest.Tools = append(manifest.Tools, entries...)
+		return SaveToolManifest(path, manifest)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to edit %s: expected a mapping with a tools list", path)
+	}
+
+	var list *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "tools" {
+			list = root.Content[i+1]
+			break
+		}
+	}
+	if list == nil {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "tools"}, list)
+	}
+	if list.Kind == yaml.ScalarNode && list.Tag == "!!null" {
+		*list = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", HeadComment: list.HeadComment, LineComment: list.LineComment}
+	}
+	if list.Kind != yaml.SequenceNode {
+		return fmt.Errorf("failed to edit %s: tools is not a list", path)
+	}
+	list.Style &^= yaml.FlowStyle // "tools: []" grows into a block list
+
+	for _, e := range entries {
+		var node yaml.Node
+		if err := node.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", e.Name, err)
+		}
+		list.Content = append(list.Content, &node)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// builtinTools is the registry as it was before the user manifest was merged
+var builtinTools []Tool
+
+// BuiltinTools returns the registry without the user manifest entries
+func BuiltinTools() []Tool {
+	if builtinTools == nil {
+		return Tools
+	}
+	return builtinTools
+}
+
+// ApplyUserManifest loads, validates and merges the user manifest into Tools.
+// On error Tools is left untouched.
+func ApplyUserManifest() error {
+	manifest, err := LoadToolManifest()
+	if err != nil {
+		return err
+	}
+	if len(manifest.Tools) == 0 {
+		return nil
+	}
+	if err := ValidateToolManifest(manifest, Tools, Scripts); err != nil {
+		return fmt.Errorf("invalid %s:\n%w", ToolManifestPath(), err)
+	}
+	builtinTools = Tools
+	Tools = MergeTools(Tools, manifest)
+	return nil
+}
+
+// defaultDependencies returns the package manager a method needs
+func defaultDependencies(method InstallMethod) []string {
+	switch method {
+	case InstallBrewFormula, InstallBrewCask:
+		return []string{"homebrew"}
+	case InstallNpm:
+		return []string{"npm"}
+	case InstallBun:
+		return []string{"bun"}
+	case InstallMAS:
+		return []string{"mas"}
+	case InstallCargo:
+		return []string{"rust"}
+	case InstallUv:
+		return []string{"uv"}
+	case InstallPipx:
+		return []string{"pipx"}
+	case InstallGo:
+		return []string{"go"}
+	}
+	return nil
+}
+
+// lookupCategory matches a category by display name, case-insensitively
+func lookupCategory(name string) (ToolCategory, bool) {
+	for _, c := range ToolCategories {
+		if strings.EqualFold(string(c), name) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+func isManifestMethod(m InstallMethod) bool {
+	for _, allowed := range manifestMethods {
+		if m == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func isLinuxMethod(m InstallMethod) bool {
+	for _, allowed := range linuxMethods {
+		if m == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func joinMethods(methods []InstallMethod) string {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = string(m)
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinCategories(categories []ToolCategory) string {
+	names := make([]string, len(categories))
+	for i, c := range categories {
+		names[i] = fmt.Sprintf("%q", string(c))
+	}
+	return strings.Join(names, ", ")
+}
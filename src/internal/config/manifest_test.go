@@ -0,0 +1,345 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseToolManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		given   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			path: "tools.yaml",
+			given: `tools:
+  - name: jq
+    category: Terminal & Git
+    method: brew
+    command: jq
+`,
+			want: []string{"jq"},
+		},
+		{
+			name:  "json",
+			path:  "tools.json",
+			given: `{"tools": [{"name": "raycast", "category": "GUI Apps", "method": "cask"}]}`,
+			want:  []string{"raycast"},
+		},
+		{
+			name:  "empty yaml",
+			path:  "tools.yaml",
+			given: "",
+		},
+		{
+			name:    "unknown yaml key",
+			path:    "tools.yaml",
+			given:   "tools:\n  - name: jq\n    formla: jq\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown json key",
+			path:    "tools.json",
+			given:   `{"tools": [{"name": "jq", "formla": "jq"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseToolManifest(tt.path, []byte(tt.given))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseToolManifest() err=%v wantErr=%v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Tools) != len(tt.want) {
+				t.Fatalf("got %d tools, want %d", len(got.Tools), len(tt.want))
+			}
+			for i, name := range tt.want {
+				if got.Tools[i].Name != name {
+					t.Errorf("tools[%d].Name = %q, want %q", i, got.Tools[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateToolManifest(t *testing.T) {
+	builtin := []Tool{
+		{Name: "homebrew", Method: InstallManual},
+		{Name: "go", Method: InstallBrewFormula, Formula: "go"},
+	}
+	scripts := []Script{{Name: "java"}}
+
+	tests := []struct {
+		name    string
+		entries []ToolManifestEntry
+		wantErr string
+	}{
+		{
+			name:    "new tool",
+			entries: []ToolManifestEntry{{Name: "jq", Category: "Terminal & Git", Method: "brew"}},
+		},
+		{
+			name:    "override only sets formula",
+			entries: []ToolManifestEntry{{Name: "go", Formula: "go@1.23"}},
+		},
+		{
+			name:    "category is case-insensitive",
+			entries: []ToolManifestEntry{{Name: "jq", Category: "terminal & git", Method: "brew"}},
+		},
+		{
+			name:    "missing name",
+			entries: []ToolManifestEntry{{Method: "brew"}},
+			wantErr: "tools[0]: name is required",
+		},
+		{
+			name:    "unknown method",
+			entries: []ToolManifestEntry{{Name: "jq", Category: "DevOps", Method: "brw"}},
+			wantErr: `unknown method "brw"`,
+		},
+		{
+			name:    "new tool without method",
+			entries: []ToolManifestEntry{{Name: "jq", Category: "DevOps"}},
+			wantErr: "method is required",
+		},
+		{
+			name:    "new mas tool",
+			entries: []ToolManifestEntry{{Name: "final-cut-pro", Category: "Mac App Store", Method: "mas", AppStoreID: 424389933}},
+		},
+		{
+			name:    "mas tool without id",
+			entries: []ToolManifestEntry{{Name: "final-cut-pro", Category: "Mac App Store", Method: "mas"}},
+			wantErr: "app_store_id is required",
+		},
+		{
+			name:    "app store id on a brew tool",
+			entries: []ToolManifestEntry{{Name: "go", AppStoreID: 1}},
+			wantErr: "app_store_id is only used by the mas method",
+		},
+		{
+			name:    "invalid min version",
+			entries: []ToolManifestEntry{{Name: "go", MinVersion: "newest"}},
+			wantErr: "min_version: invalid version constraint",
+		},
+		{
+			name:    "unknown category",
+			entries: []ToolManifestEntry{{Name: "jq", Category: "Misc", Method: "brew"}},
+			wantErr: `unknown category "Misc"`,
+		},
+		{
+			name:    "unknown dependency",
+			entries: []ToolManifestEntry{{Name: "jq", Category: "DevOps", Method: "brew", Dependencies: []string{"nope"}}},
+			wantErr: `unknown dependency "nope"`,
+		},
+		{
+			name: "dependency on another manifest entry",
+			entries: []ToolManifestEntry{
+				{Name: "a", Category: "DevOps", Method: "brew"},
+				{Name: "b", Category: "DevOps", Method: "brew", Dependencies: []string{"a"}},
+			},
+		},
+		{
+			name: "cycle between manifest entries",
+			entries: []ToolManifestEntry{
+				{Name: "aa", Category: "DevOps", Method: "brew", Dependencies: []string{"bb"}},
+				{Name: "bb", Category: "DevOps", Method: "brew", Dependencies: []string{"aa"}},
+			},
+			wantErr: "dependency cycle aa -> bb -> aa",
+		},
+		{
+			name: "override closing a cycle through built-in tools",
+			entries: []ToolManifestEntry{
+				{Name: "go", Dependencies: []string{"homebrew"}},
+				{Name: "homebrew", Dependencies: []string{"go"}},
+			},
+			wantErr: "dependency cycle homebrew -> go -> homebrew",
+		},
+		{
+			name:    "unknown script",
+			entries: []ToolManifestEntry{{Name: "go", Scripts: []string{"nope"}}},
+			wantErr: `unknown script "nope"`,
+		},
+		{
+			name:    "linux mapping",
+			entries: []ToolManifestEntry{{Name: "go", Linux: map[string]string{"apt": "golang-go"}}},
+		},
+		{
+			name:    "unknown linux package manager",
+			entries: []ToolManifestEntry{{Name: "go", Linux: map[string]string{"yum": "golang"}}},
+			wantErr: `unknown linux package manager "yum"`,
+		},
+		{
+			name: "duplicate entry",
+			entries: []ToolManifestEntry{
+				{Name: "go", Formula: "go"},
+				{Name: "go", Formula: "go@1.23"},
+			},
+			wantErr: `tools[1] ("go"): duplicate entry`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateToolManifest(ToolManifest{Tools: tt.entries}, builtin, scripts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateToolManifest() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateToolManifest() err=%v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeTools(t *testing.T) {
+	builtin := []Tool{
+		{
+			Name:      "openjdk",
+			Command:   "java",
+			Formula:   "openjdk",
+			Method:    InstallBrewFormula,
+			Category:  CategoryRuntimes,
+			CheckFn:   func() CheckResult { return Installed() },
+			InstallFn: func() error { return nil },
+		},
+	}
+
+	manifest := ToolManifest{Tools: []ToolManifestEntry{
+		{Name: "openjdk", Formula: "openjdk@21"},
+		{Name: "raycast", Category: "GUI Apps", Method: "cask"},
+	}}
+
+	merged := MergeTools(builtin, manifest)
+	if len(merged) != 2 {
+		t.Fatalf("got %d tools, want 2", len(merged))
+	}
+
+	jdk := merged[0]
+	if jdk.Formula != "openjdk@21" {
+		t.Errorf("override Formula = %q, want openjdk@21", jdk.Formula)
+	}
+	if jdk.Command != "java" || jdk.CheckFn == nil {
+		t.Error("override should keep unset fields and the custom check")
+	}
+	if jdk.InstallFn != nil {
+		t.Error("changing the formula should drop the built-in installer")
+	}
+	if jdk.Source != SourceOverride {
+		t.Errorf("override Source = %q, want %q", jdk.Source, SourceOverride)
+	}
+
+	raycast := merged[1]
+	if raycast.Category != CategoryGUIApps || raycast.Method != InstallBrewCask {
+		t.Errorf("new tool = %+v", raycast)
+	}
+	if raycast.Source != SourceManifest {
+		t.Errorf("new tool Source = %q, want %q", raycast.Source, SourceManifest)
+	}
+	if raycast.Formula != "raycast" {
+		t.Errorf("Formula defaults to the name, got %q", raycast.Formula)
+	}
+	if len(raycast.Dependencies) != 1 || raycast.Dependencies[0] != "homebrew" {
+		t.Errorf("Dependencies default to the package manager, got %v", raycast.Dependencies)
+	}
+
+	if builtin[0].Formula != "openjdk" {
+		t.Error("MergeTools must not mutate the built-in registry")
+	}
+}
+
+func TestApplyUserManifest(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	original := Tools
+	t.Cleanup(func() { Tools, builtinTools = original, nil })
+
+	dir := filepath.Join(home, ".config", "jterrazz")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	manifest := "tools:\n  - name: jq\n    category: Terminal & Git\n    method: brew\n    command: jq\n"
+	if err := os.WriteFile(filepath.Join(dir, "tools.yaml"), []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ApplyUserManifest(); err != nil {
+		t.Fatalf("ApplyUserManifest() error = %v", err)
+	}
+	if GetToolByName("jq") == nil {
+		t.Fatal("expected jq to be merged into Tools")
+	}
+	for _, tool := range BuiltinTools() {
+		if tool.Name == "jq" {
+			t.Fatal("BuiltinTools() should not include manifest entries")
+		}
+	}
+
+	invalid := "tools:\n  - name: broken\n    method: brew\n"
+	if err := os.WriteFile(filepath.Join(dir, "tools.yaml"), []byte(invalid), 0600); err != nil {
+		t.Fatal(err)
+	}
+	Tools = original
+	if err := ApplyUserManifest(); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if GetToolByName("broken") != nil {
+		t.Fatal("invalid manifest must leave Tools untouched")
+	}
+}
+
+func TestAppendToolManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	handEdited := `# My tools
+tools:
+  # Terminal
+  - name: jq # json
+    method: brew
+    category: Terminal & Git
+`
+	if err := os.WriteFile(path, []byte(handEdited), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	added := ToolManifestEntry{Name: "raycast", Category: "GUI Apps", Method: "cask", Formula: "raycast"}
+	if err := AppendToolManifest(path, []ToolManifestEntry{added}); err != nil {
+		t.Fatalf("AppendToolManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), handEdited) {
+		t.Errorf("the hand-edited part changed:\n%s", data)
+	}
+	manifest, err := ParseToolManifest(path, data)
+	if err != nil {
+		t.Fatalf("ParseToolManifest() error = %v", err)
+	}
+	if len(manifest.Tools) != 2 || manifest.Tools[1].Name != "raycast" || manifest.Tools[1].Formula != "raycast" {
+		t.Errorf("tools = %+v, want jq then raycast", manifest.Tools)
+	}
+
+	// A missing manifest is created
+	created := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := AppendToolManifest(created, []ToolManifestEntry{added}); err != nil {
+		t.Fatalf("AppendToolManifest() error = %v", err)
+	}
+	data, _ = os.ReadFile(created)
+	if manifest, err := ParseToolManifest(created, data); err != nil || len(manifest.Tools) != 1 {
+		t.Errorf("created manifest = %+v, %v", manifest, err)
+	}
+}
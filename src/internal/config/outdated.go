@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// OutdatedPackage is a package its manager reports as having a newer version
+type OutdatedPackage struct {
+	Name       string
+	Method     InstallMethod
+	AppStoreID int // For mas apps, which are matched on it rather than on their title
+	Current    string
+	Latest     string
+}
+
+// OutdatedTool is a registry tool with a newer version available
+type OutdatedTool struct {
+	Tool    Tool
+	Current string
+	Latest  string
+}
+
+// CheckOutdated asks every available package manager for its outdated
+// packages and maps them back to tools. Managers that fail are reported in
+// the error while the others still answer.
+func CheckOutdated(tools []Tool) ([]OutdatedTool, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		packages []OutdatedPackage
+		errs     []error
+	)
+	for _, pm := range PackageManagers {
+		if pm.OutdatedFn == nil || !CommandExists(pm.RequiresCmd) {
+			continue
+		}
+		wg.Add(1)
+		go func(pm PackageManager) {
+			defer wg.Done()
+			found, err := pm.OutdatedFn()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", pm.Name, err))
+				return
+			}
+			packages = append(packages, found...)
+		}(pm)
+	}
+	wg.Wait()
+
+	return MatchOutdated(tools, packages), errors.Join(errs...)
+}
+
+// MatchOutdated maps outdated packages to the tools installing them, in tools order.
+// Mac App Store apps are matched on their AppStoreID, others on their Formula.
+func MatchOutdated(tools []Tool, packages []OutdatedPackage) []OutdatedTool {
+	byKey := make(map[string]OutdatedPackage, len(packages))
+	byAppStoreID := make(map[int]OutdatedPackage)
+	for _, p := range packages {
+		if p.Method == InstallMAS {
+			byAppStoreID[p.AppStoreID] = p
+			continue
+		}
+		byKey[string(p.Method)+":"+p.Name] = p
+	}
+
+	var outdated []OutdatedTool
+	for _, t := range tools {
+		if t.Method == InstallMAS {
+			if p, ok := byAppStoreID[t.AppStoreID]; ok && t.AppStoreID != 0 {
+				outdated = append(outdated, OutdatedTool{Tool: t, Current: p.Current, Latest: p.Latest})
+			}
+			continue
+		}
+		if t.Formula == "" {
+			continue
+		}
+		p, ok := byKey[string(t.Method)+":"+t.Formula]
+		if !ok {
+			// Brew reports tap formulae by their short name (oven-sh/bun/bun -> bun)
+			p, ok = byKey[string(t.Method)+":"+path.Base(t.Formula)]
+		}
+		if ok {
+			outdated = append(outdated, OutdatedTool{Tool: t, Current: p.Current, Latest: p.Latest})
+		}
+	}
+	return outdated
+}
+
+// =============================================================================
+// Outdated Functions
+// =============================================================================
+
+// outdatedBrew reads the outdated formulae and casks from the brew inventory
+func outdatedBrew() ([]OutdatedPackage, error) {
+	inv, err := tool.Brew()
+	if err != nil {
+		return nil, err
+	}
+	var packages []OutdatedPackage
+	for _, p := range inv.Outdated() {
+		method := InstallBrewFormula
+		if p.Cask {
+			method = InstallBrewCask
+		}
+		packages = append(packages, OutdatedPackage{Name: p.Name, Method: method, Current: p.Version, Latest: p.Latest})
+	}
+	return packages, nil
+}
+
+func outdatedNpm() ([]OutdatedPackage, error) {
+	var out bytes.Buffer
+	err := tool.RunCmd(tool.Cmd{Name: "npm", Args: []string{"outdated", "-g", "--json"}, Stdout: &out})
+	// npm outdated exits 1 when something is outdated
+	if err != nil && len(bytes.TrimSpace(out.Bytes())) == 0 {
+		return nil, err
+	}
+	return parseNpmOutdated(out.Bytes())
+}
+
+func parseNpmOutdated(data []byte) ([]OutdatedPackage, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var report map[string]struct {
+		Current string `json:"current"`
+		Latest  string `json:"latest"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse npm outdated: %w", err)
+	}
+
+	var packages []OutdatedPackage
+	for name, entry := range report {
+		packages = append(packages, OutdatedPackage{Name: name, Method: InstallNpm, Current: entry.Current, Latest: entry.Latest})
+	}
+	return packages, nil
+}
+
+// bunGlobalDir is the project bun installs global packages into
+func bunGlobalDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".bun", "install", "global")
+}
+
+func outdatedBun() ([]OutdatedPackage, error) {
+	dir := bunGlobalDir()
+	if _, err := os.Stat(filepath.Join(dir, "package.json")); err != nil {
+		return nil, nil // No global packages
+	}
+
+	var out bytes.Buffer
+	if err := tool.RunCmd(tool.Cmd{Name: "bun", Args: []string{"outdated"}, Dir: dir, Stdout: &out}); err != nil {
+		return nil, err
+	}
+	return parseBunOutdated(out.String()), nil
+}
+
+// parseBunOutdated reads the table printed by `bun outdated`:
+//
+//	| Package       | Current | Update | Latest |
+//	| @openai/codex | 0.1.0   | 0.1.0  | 0.2.0  |
+//
+// Recent versions draw the borders with box characters (│) instead of pipes.
+func parseBunOutdated(output string) []OutdatedPackage {
+	var packages []OutdatedPackage
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.ReplaceAll(line, "│", "|")
+		if !strings.HasPrefix(strings.TrimSpace(line), "|") {
+			continue
+		}
+
+		var cells []string
+		for _, cell := range strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|") {
+			cells = append(cells, strings.TrimSpace(cell))
+		}
+		if len(cells) < 4 || cells[0] == "Package" || strings.Trim(cells[0], "-") == "" {
+			continue
+		}
+
+		name := strings.TrimSuffix(cells[0], " (dev)")
+		packages = append(packages, OutdatedPackage{Name: name, Method: InstallBun, Current: cells[1], Latest: cells[3]})
+	}
+	return packages
+}
+
+func outdatedMas() ([]OutdatedPackage, error) {
+	out, err := tool.Output("mas", "outdated")
+	if err != nil {
+		return nil, err
+	}
+	return parseMasOutdated(string(out)), nil
+}
+
+// masOutdatedLine matches `497799835  Xcode  (15.4 -> 16.0)`
+var masOutdatedLine = regexp.MustCompile(`^\s*(\d+)\s+(.+?)\s+\((\S+)\s+->\s+(\S+)\)\s*$`)
+
+// parseMasOutdated reads `mas outdated`: App Store id, title and versions
+func parseMasOutdated(output string) []OutdatedPackage {
+	var packages []OutdatedPackage
+	for _, line := range strings.Split(tool.StripAnsi(output), "\n") {
+		if m := masOutdatedLine.FindStringSubmatch(line); m != nil {
+			id, _ := strconv.Atoi(m[1])
+			packages = append(packages, OutdatedPackage{Name: m[2], Method: InstallMAS, AppStoreID: id, Current: m[3], Latest: m[4]})
+		}
+	}
+	return packages
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestParseOutdated(t *testing.T) {
+	npmJSON := `{
+  "eas-cli": {"current": "16.0.0", "wanted": "16.3.1", "latest": "16.3.1", "location": "/opt/homebrew/lib/node_modules/eas-cli"}
+}`
+	bunTable := `bun outdated v1.2.0 (b0c5a765)
+┌───────────────┬─────────┬────────┬────────┐
+│ Package       │ Current │ Update │ Latest │
+├───────────────┼─────────┼────────┼────────┤
+│ @openai/codex │ 0.1.0   │ 0.1.0  │ 0.2.0  │
+├───────────────┼─────────┼────────┼────────┤
+│ opencode-ai   │ 0.5.1   │ 0.5.1  │ 0.6.0  │
+└───────────────┴─────────┴────────┴────────┘
+`
+	bunPipes := `| Package              | Current | Update | Latest |
+|----------------------|---------|--------|--------|
+| @google/gemini-cli   | 0.1.0   | 0.1.0  | 0.1.5  |
+`
+	masList := `497799835   Xcode                (15.4 -> 16.0)
+1440147259  AdGuard for Safari   (1.11.10 -> 1.11.11)
+`
+
+	npm, err := parseNpmOutdated([]byte(npmJSON))
+	if err != nil {
+		t.Fatalf("parseNpmOutdated() error = %v", err)
+	}
+	if empty, err := parseNpmOutdated([]byte("\n")); err != nil || empty != nil {
+		t.Errorf("parseNpmOutdated(empty) = %v, %v, want nothing", empty, err)
+	}
+
+	tests := []struct {
+		name     string
+		given    []OutdatedPackage
+		expected []OutdatedPackage
+	}{
+		{"npm", npm, []OutdatedPackage{
+			{Name: "eas-cli", Method: InstallNpm, Current: "16.0.0", Latest: "16.3.1"},
+		}},
+		{"bun box table", parseBunOutdated(bunTable), []OutdatedPackage{
+			{Name: "@openai/codex", Method: InstallBun, Current: "0.1.0", Latest: "0.2.0"},
+			{Name: "opencode-ai", Method: InstallBun, Current: "0.5.1", Latest: "0.6.0"},
+		}},
+		{"bun pipe table", parseBunOutdated(bunPipes), []OutdatedPackage{
+			{Name: "@google/gemini-cli", Method: InstallBun, Current: "0.1.0", Latest: "0.1.5"},
+		}},
+		{"mas", parseMasOutdated(masList), []OutdatedPackage{
+			{Name: "Xcode", Method: InstallMAS, AppStoreID: 497799835, Current: "15.4", Latest: "16.0"},
+			{Name: "AdGuard for Safari", Method: InstallMAS, AppStoreID: 1440147259, Current: "1.11.10", Latest: "1.11.11"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.given, tt.expected) {
+				t.Errorf("got %+v, want %+v", tt.given, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchOutdated(t *testing.T) {
+	tools := []Tool{
+		{Name: "go", Method: InstallBrewFormula, Formula: "go"},
+		{Name: "bun", Method: InstallBrewFormula, Formula: "oven-sh/bun/bun"},
+		{Name: "zed", Method: InstallBrewCask, Formula: "zed"},
+		{Name: "eas", Method: InstallNpm, Formula: "eas-cli"},
+		{Name: "codex", Method: InstallBun, Formula: "@openai/codex"},
+		{Name: "python", Method: InstallBrewFormula, Formula: "python"},
+		{Name: "xcode", Method: InstallMAS, AppStoreID: 497799835, App: "Xcode"},
+		{Name: "pages", Method: InstallMAS, AppStoreID: 409201541, App: "Pages"},
+	}
+	packages := []OutdatedPackage{
+		{Name: "bun", Method: InstallBrewFormula, Current: "1.1.2", Latest: "1.2.0"},
+		{Name: "zed", Method: InstallBrewFormula, Current: "1", Latest: "2"}, // Not the cask
+		{Name: "eas-cli", Method: InstallNpm, Current: "16.0.0", Latest: "16.3.1"},
+		{Name: "@openai/codex", Method: InstallBun, Current: "0.1.0", Latest: "0.2.0"},
+		{Name: "unrelated", Method: InstallNpm, Current: "1", Latest: "2"},
+		{Name: "Xcode 16", Method: InstallMAS, AppStoreID: 497799835, Current: "15.4", Latest: "16.0"}, // Renamed title
+		{Name: "Pages", Method: InstallMAS, AppStoreID: 1, Current: "1", Latest: "2"},                  // Another app with the same title
+	}
+
+	var got []string
+	for _, o := range MatchOutdated(tools, packages) {
+		got = append(got, o.Tool.Name+" "+o.Current+" "+o.Latest)
+	}
+	expected := []string{"bun 1.1.2 1.2.0", "eas 16.0.0 16.3.1", "codex 0.1.0 0.2.0", "xcode 15.4 16.0"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("MatchOutdated() = %v, want %v", got, expected)
+	}
+}
+
+func TestCheckOutdated(t *testing.T) {
+	fake := tool.NewFakeRunner().
+		WithPath("brew", "npm").
+		On("brew info --json=v2 --installed", tool.FakeResponse{Stdout: `{"formulae": [{"name": "go", "full_name": "go", "versions": {"stable": "1.24.1"}, "installed": [{"version": "1.24.0"}], "linked_keg": "1.24.0", "outdated": true}], "casks": [{"token": "zed", "version": "0.180.2", "installed": "0.180.2", "outdated": false}]}`}).
+		On("npm outdated -g --json", tool.FakeResponse{Stdout: `{"eas-cli": {"current": "16.0.0", "latest": "16.3.1"}}`, Err: errors.New("exit status 1")})
+	t.Cleanup(tool.SetRunner(fake))
+
+	tools := []Tool{
+		{Name: "go", Method: InstallBrewFormula, Formula: "go"},
+		{Name: "zed", Method: InstallBrewCask, Formula: "zed"},
+		{Name: "eas", Method: InstallNpm, Formula: "eas-cli"},
+	}
+	outdated, err := CheckOutdated(tools)
+	if err != nil {
+		t.Fatalf("CheckOutdated() error = %v", err)
+	}
+
+	var got []string
+	for _, o := range outdated {
+		got = append(got, o.Tool.Name+" "+o.Latest)
+	}
+	sort.Strings(got)
+	if expected := []string{"eas 16.3.1", "go 1.24.1"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("CheckOutdated() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// PathProblem is a tool command that is shadowed, duplicated or not owned by
+// its install method on PATH
+type PathProblem struct {
+	Tool    string
+	Problem string // e.g. "node runs /usr/bin/node, which shadows the nvm one at ..."
+	Fix     string // Concrete fix suggestion
+}
+
+// binaryOwner is where an install method puts the commands it installs
+type binaryOwner struct {
+	Name     string   // e.g. "brew"
+	Prefixes []string // The command must resolve under one of these
+	Fix      string   // How to put them first on PATH
+}
+
+// owns reports whether path (or the file it links to) is under a prefix
+func (o binaryOwner) owns(path string) bool {
+	candidates := []string{path}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		candidates = append(candidates, resolved)
+	}
+	for _, candidate := range candidates {
+		for _, prefix := range o.Prefixes {
+			if candidate == prefix || strings.HasPrefix(candidate, prefix+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BrewPrefix returns $HOMEBREW_PREFIX, or the default prefix of the platform
+func BrewPrefix() string {
+	if prefix := os.Getenv("HOMEBREW_PREFIX"); prefix != "" {
+		return prefix
+	}
+	p := CurrentPlatform()
+	switch {
+	case p.IsLinux():
+		return "/home/linuxbrew/.linuxbrew"
+	case p.Arch == "arm64":
+		return "/opt/homebrew"
+	}
+	return "/usr/local"
+}
+
+// binaryOwner returns where the install method of the tool puts its command.
+// Custom installers and casks (whose apps ship their own CLIs) have none.
+func (t Tool) binaryOwner() (binaryOwner, bool) {
+	if t.InstallFn != nil || t.RemoteScript != nil {
+		return binaryOwner{}, false
+	}
+
+	home := os.Getenv("HOME")
+	nvm := binaryOwner{
+		Name:     "nvm",
+		Prefixes: []string{filepath.Join(home, ".nvm")},
+		Fix:      "Load nvm last in ~/.zshrc, then run: nvm alias default node",
+	}
+	switch t.Method {
+	case InstallBrewFormula:
+		return binaryOwner{
+			Name:     "brew",
+			Prefixes: []string{BrewPrefix()},
+			Fix:      fmt.Sprintf(`Add eval "$(%s/bin/brew shellenv)" to ~/.zprofile`, BrewPrefix()),
+		}, true
+	case InstallNvm:
+		return nvm, true
+	case InstallNpm:
+		// Global packages live next to the node that installed them
+		nvm.Name = "npm"
+		nvm.Prefixes = append(nvm.Prefixes, BrewPrefix())
+		return nvm, true
+	case InstallBun:
+		return binaryOwner{
+			Name:     "bun",
+			Prefixes: []string{filepath.Join(home, ".bun", "bin")},
+			Fix:      `Add export PATH="$HOME/.bun/bin:$PATH" to ~/.zshrc`,
+		}, true
+	case InstallRelease:
+		return binaryOwner{
+			Name:     "release",
+			Prefixes: []string{ReleaseBinDir()},
+			Fix:      fmt.Sprintf(`Add export PATH="%s:$PATH" to ~/.zshrc`, ReleaseBinDir()),
+		}, true
+	case InstallCargo:
+		return binaryOwner{
+			Name:     "cargo",
+			Prefixes: []string{CargoBinDir()},
+			Fix:      fmt.Sprintf(`Add export PATH="%s:$PATH" to ~/.zshrc`, CargoBinDir()),
+		}, true
+	case InstallUv:
+		return binaryOwner{
+			Name:     "uv",
+			Prefixes: []string{UvBinDir()},
+			Fix:      fmt.Sprintf(`Add export PATH="%s:$PATH" to ~/.zshrc`, UvBinDir()),
+		}, true
+	case InstallPipx:
+		return binaryOwner{
+			Name:     "pipx",
+			Prefixes: []string{PipxBinDir()},
+			Fix:      fmt.Sprintf(`Add export PATH="%s:$PATH" to ~/.zshrc`, PipxBinDir()),
+		}, true
+	case InstallGo:
+		return binaryOwner{
+			Name:     "go",
+			Prefixes: []string{GoBinDir()},
+			Fix:      fmt.Sprintf(`Add export PATH="%s:$PATH" to ~/.zshrc`, GoBinDir()),
+		}, true
+	}
+	return binaryOwner{}, false
+}
+
+// CheckPath reports the tools whose command is on pathEnv more than once, or
+// resolves to a binary their install method did not install
+func CheckPath(tools []Tool, pathEnv string) []PathProblem {
+	var problems []PathProblem
+	for _, t := range tools {
+		if t.Command == "" || t.Unsupported {
+			continue
+		}
+		owner, hasOwner := t.binaryOwner()
+		paths := tool.LookPathAll(t.Command, pathEnv)
+		if len(paths) == 0 {
+			if binary, ok := t.releaseBinary(); ok {
+				problems = append(problems, PathProblem{
+					Tool:    t.Name,
+					Problem: fmt.Sprintf("%s is installed at %s, which is not on PATH", t.Command, binary),
+					Fix:     owner.Fix,
+				})
+			}
+			continue
+		}
+
+		// macOS /usr/bin/java is a stub running the JDK picked by java_home
+		if CurrentPlatform().IsMac() && paths[0] == macJavaStub {
+			if hasOwner && owner.Name == "brew" {
+				if problem, ok := checkJavaHome(t); ok {
+					problems = append(problems, problem)
+					continue
+				}
+			}
+			hasOwner = false
+		}
+
+		if hasOwner {
+			if problem, ok := checkOwnership(t, owner, paths); ok {
+				problems = append(problems, problem)
+				continue
+			}
+		}
+		if len(paths) > 1 {
+			problems = append(problems, PathProblem{
+				Tool:    t.Name,
+				Problem: fmt.Sprintf("%s is on PATH %d times, %s runs", t.Command, len(paths), paths[0]),
+				Fix:     "Remove the copies you don't use: " + strings.Join(paths[1:], ", "),
+			})
+		}
+	}
+	return problems
+}
+
+// macJavaStub is the java launcher macOS ships, which runs the JDK picked by java_home
+const macJavaStub = "/usr/bin/java"
+
+// checkJavaHome returns a problem when the JDK java_home picks is not the brew one
+func checkJavaHome(t Tool) (PathProblem, bool) {
+	formula := t.Formula
+	if formula == "" {
+		formula = t.Name
+	}
+	brew := binaryOwner{
+		Name:     "brew",
+		Prefixes: []string{filepath.Join(BrewPrefix(), "opt", formula), filepath.Join(BrewPrefix(), "Cellar", formula)},
+	}
+	fix := "Run: j setup java, then remove the other JDKs from /Library/Java/JavaVirtualMachines"
+
+	out, err := tool.Output("/usr/libexec/java_home")
+	javaHome := strings.TrimSpace(string(out))
+	if err != nil || javaHome == "" {
+		return PathProblem{
+			Tool:    t.Name,
+			Problem: fmt.Sprintf("%s runs %s, but java_home finds no JDK", t.Command, macJavaStub),
+			Fix:     fix,
+		}, true
+	}
+	if brew.owns(javaHome) {
+		return PathProblem{}, false
+	}
+	return PathProblem{
+		Tool:    t.Name,
+		Problem: fmt.Sprintf("%s runs the JDK at %s, which is not the brew %s", t.Command, javaHome, formula),
+		Fix:     fix,
+	}, true
+}
+
+// checkOwnership returns a problem when the command that runs was not installed by owner
+func checkOwnership(t Tool, owner binaryOwner, paths []string) (PathProblem, bool) {
+	if owner.owns(paths[0]) {
+		return PathProblem{}, false
+	}
+
+	for _, path := range paths[1:] {
+		if owner.owns(path) {
+			return PathProblem{
+				Tool:    t.Name,
+				Problem: fmt.Sprintf("%s runs %s, which shadows the %s one at %s", t.Command, paths[0], owner.Name, path),
+				Fix:     owner.Fix,
+			}, true
+		}
+	}
+
+	fix := "Remove " + paths[0] + " or reinstall " + t.Name + " with " + owner.Name
+	if t.Method == InstallNvm {
+		fix = "Run: nvm install node && nvm alias default node"
+	} else if args, err := t.installCommand(); err == nil {
+		fix = "Install the " + owner.Name + " one: " + strings.Join(args, " ")
+	}
+	return PathProblem{
+		Tool:    t.Name,
+		Problem: fmt.Sprintf("%s runs %s, which was not installed by %s", t.Command, paths[0], owner.Name),
+		Fix:     fix,
+	}, true
+}
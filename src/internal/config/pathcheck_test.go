@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestCheckPath(t *testing.T) {
+	useAppleSilicon(t)
+	home := t.TempDir()
+	brew := filepath.Join(home, "homebrew")
+	t.Setenv("HOME", home)
+	t.Setenv("HOMEBREW_PREFIX", brew)
+
+	dirs := map[string]string{
+		"brew":    filepath.Join(brew, "bin"),
+		"nvm":     filepath.Join(home, ".nvm", "versions", "node", "v22.1.0", "bin"),
+		"bun":     filepath.Join(home, ".bun", "bin"),
+		"system":  filepath.Join(home, "usr", "bin"),
+		"release": ReleaseBinDir(),
+	}
+	install := func(dir, command string) {
+		t.Helper()
+		if err := os.MkdirAll(dirs[dir], 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dirs[dir], command), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	install("brew", "go")
+	install("system", "go")
+	install("system", "node")
+	install("nvm", "node")
+	install("system", "python3")
+	install("brew", "jq")
+	install("system", "codex")
+	install("bun", "codex")
+	install("system", "git")
+	install("release", "mole")
+
+	path := strings.Join([]string{dirs["brew"], dirs["system"], dirs["nvm"], dirs["bun"]}, string(os.PathListSeparator))
+	tests := []struct {
+		name     string
+		given    Tool
+		expected string // Problem, "" when none
+	}{
+		{"brew first", Tool{Name: "go", Command: "go", Method: InstallBrewFormula}, "go is on PATH 2 times"},
+		{"nvm shadowed", Tool{Name: "node", Command: "node", Method: InstallNvm}, "which shadows the nvm one"},
+		{"not installed by brew", Tool{Name: "python", Command: "python3", Method: InstallBrewFormula, Formula: "python"}, "which was not installed by brew"},
+		{"owned once", Tool{Name: "jq", Command: "jq", Method: InstallBrewFormula}, ""},
+		{"bun shadowed", Tool{Name: "codex", Command: "codex", Method: InstallBun}, "which shadows the bun one"},
+		{"no owner", Tool{Name: "git", Command: "git", Method: InstallManual}, ""},
+		{"not on PATH", Tool{Name: "rg", Command: "rg", Method: InstallBrewFormula}, ""},
+		{"release dir not on PATH", Tool{Name: "mole", Command: "mole", Method: InstallRelease}, "which is not on PATH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := CheckPath([]Tool{tt.given}, path)
+			if tt.expected == "" {
+				if len(problems) > 0 {
+					t.Errorf("CheckPath() = %+v, expected no problem", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0].Problem, tt.expected) || problems[0].Fix == "" {
+				t.Errorf("CheckPath() = %+v, expected one problem with %q and a fix", problems, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckJavaHome(t *testing.T) {
+	useAppleSilicon(t)
+	root := t.TempDir()
+	brew := filepath.Join(root, "homebrew")
+	t.Setenv("HOMEBREW_PREFIX", brew)
+
+	cellar := filepath.Join(brew, "Cellar", "openjdk", "23.0.1", "libexec", "openjdk.jdk", "Contents", "Home")
+	if err := os.MkdirAll(cellar, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// The symlink j setup java creates, as java_home reports it
+	linked := filepath.Join(root, "JavaVirtualMachines", "openjdk.jdk")
+	if err := os.MkdirAll(filepath.Dir(linked), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(brew, "Cellar", "openjdk", "23.0.1", "libexec", "openjdk.jdk"), linked); err != nil {
+		t.Fatal(err)
+	}
+
+	openjdk := Tool{Name: "openjdk", Command: "java", Method: InstallBrewFormula, Formula: "openjdk"}
+	tests := []struct {
+		name     string
+		javaHome tool.FakeResponse
+		expected string // Problem, "" when none
+	}{
+		{"brew jdk", tool.FakeResponse{Stdout: filepath.Join(linked, "Contents", "Home") + "\n"}, ""},
+		{"other jdk", tool.FakeResponse{Stdout: "/Library/Java/JavaVirtualMachines/temurin-17.jdk/Contents/Home\n"}, "which is not the brew openjdk"},
+		{"no jdk", tool.FakeResponse{Err: errors.New("exit status 1")}, "java_home finds no JDK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(tool.SetRunner(tool.NewFakeRunner().On("/usr/libexec/java_home", tt.javaHome)))
+			problem, ok := checkJavaHome(openjdk)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("checkJavaHome() = %+v, expected no problem", problem)
+				}
+				return
+			}
+			if !ok || !strings.Contains(problem.Problem, tt.expected) || problem.Fix == "" {
+				t.Errorf("checkJavaHome() = %+v, expected a problem with %q and a fix", problem, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// PlanStep is one action a command would take, as shown by --dry-run
+type PlanStep struct {
+	Name     string
+	Method   string   // brew, cask, npm, bun, sh, script...
+	Commands []string // Command lines that would run, in order
+	Note     string   // What happens when the step runs Go code instead of commands
+	Skip     string   // Why the step would be skipped (empty = it would run)
+}
+
+// =============================================================================
+// Install
+// =============================================================================
+
+// PlanInstall returns the install plan for the named tools and their
+// dependencies, in dependency order. No names plans every installable tool.
+func PlanInstall(names []string) ([]PlanStep, error) {
+	for _, name := range names {
+		if GetToolByName(name) == nil {
+			return nil, UnknownError("tool", name)
+		}
+	}
+
+	tools := GetToolsInDependencyOrder()
+	if len(names) > 0 {
+		tools = GetDependencyOrder(names)
+	}
+
+	var steps []PlanStep
+	for _, t := range tools {
+		steps = append(steps, planTool(t)...)
+	}
+	return steps, nil
+}
+
+// planTool returns the install step of a tool followed by its post-install scripts
+func planTool(t Tool) []PlanStep {
+	step := PlanStep{Name: t.Name, Method: planMethod(t)}
+	if t.Check().Installed {
+		step.Skip = "already installed"
+		return []PlanStep{step}
+	}
+
+	if t.RemoteScript != nil && t.InstallFn == nil {
+		step.Note = planRemoteScript(t)
+	} else if t.Method == InstallRelease && t.InstallFn == nil {
+		step.Note = planRelease(t)
+	} else if step.Commands = t.InstallCommands(); len(step.Commands) == 0 {
+		step.Commands = nil
+		step.Note = "no automatic installer, install it manually"
+	}
+	steps := []PlanStep{step}
+	for _, name := range t.Scripts {
+		if script := GetScriptByName(name); script != nil {
+			steps = append(steps, planScript(*script))
+		}
+	}
+	return steps
+}
+
+// InstallCommands returns the command lines Install would run
+func (t Tool) InstallCommands() []string {
+	return tool.Record(t.Install)
+}
+
+// planRemoteScript describes an installer script run, which downloads instead of running commands
+func planRemoteScript(t Tool) string {
+	check := "ask before running it (no pinned SHA-256)"
+	if t.RemoteScript.SHA256 != "" {
+		check = "verify its pinned SHA-256 (ask on mismatch)"
+	}
+	return fmt.Sprintf("download %s, %s, run it", t.RemoteScript.URL, check)
+}
+
+// planRelease describes a release install, which downloads instead of running commands
+func planRelease(t Tool) string {
+	p := CurrentPlatform()
+	if t.Release == nil || t.Release.Asset(p) == "" {
+		return fmt.Sprintf("no release for %s/%s", p.OS, p.Arch)
+	}
+	return fmt.Sprintf("download %s, verify its SHA-256, extract %s to %s",
+		t.Release.AssetURL(p), strings.Join(t.Release.Binaries, ", "), ReleaseBinDir())
+}
+
+// planMethod returns the method label shown in plans
+func planMethod(t Tool) string {
+	if t.Method == InstallBrewCask {
+		return string(InstallBrewCask)
+	}
+	return t.Method.String()
+}
+
+// =============================================================================
+// Upgrade
+// =============================================================================
+
+// PlanUpgradeAll returns the plan of `j upgrade --all`
+func PlanUpgradeAll() []PlanStep {
+	steps := make([]PlanStep, 0, len(PackageManagers))
+	for _, pm := range PackageManagers {
+		steps = append(steps, PlanUpgrade(pm))
+	}
+	return steps
+}
+
+// PlanUpgrade returns the plan of upgrading one package manager
+func PlanUpgrade(pm PackageManager) PlanStep {
+	step := PlanStep{Name: pm.Name, Method: pm.Flag}
+	if !CommandExists(pm.RequiresCmd) {
+		step.Skip = pm.RequiresCmd + " not found"
+		return step
+	}
+	step.Commands = joinCommands(pm.UpgradeCommands())
+	if len(step.Commands) == 0 {
+		step.Note = "no packages installed"
+	}
+	return step
+}
+
+// =============================================================================
+// Clean
+// =============================================================================
+
+// PlanClean returns the plan of cleaning the given items
+func PlanClean(cleanables []Cleanable) []PlanStep {
+	steps := make([]PlanStep, 0, len(cleanables))
+	for _, c := range cleanables {
+		step := PlanStep{Name: c.Name, Method: "sh", Commands: joinCommands(c.Commands)}
+		if c.CleanFn != nil {
+			step.Method = "builtin"
+			step.Commands = nil
+			step.Note = c.Description
+		}
+		if c.RequiresCmd != "" && !CommandExists(c.RequiresCmd) {
+			step.Skip = c.RequiresCmd + " not found"
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// =============================================================================
+// Setup
+// =============================================================================
+
+// PlanSetup returns the plan of running every setup script.
+// Scripts whose check already passes are skipped.
+func PlanSetup() []PlanStep {
+	steps := make([]PlanStep, 0, len(Scripts))
+	for _, script := range Scripts {
+		steps = append(steps, planScript(script))
+	}
+	return steps
+}
+
+// PlanProfileSetup returns the plan of running the setup scripts of the
+// profile, then installing its favorite skills not in installedSkills
+func PlanProfileSetup(p Profile, installedSkills []string) []PlanStep {
+	var steps []PlanStep
+	for _, script := range Scripts {
+		if p.IncludesScript(script.Name) {
+			steps = append(steps, planScript(script))
+		}
+	}
+	for _, s := range p.FavoriteSkills() {
+		step := PlanStep{Name: s.Skill, Method: "skill", Commands: []string{"skills add " + s.Repo + " -g -y --skill " + s.Skill}}
+		if slices.Contains(installedSkills, s.Skill) {
+			step.Skip = "already installed"
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// planScript returns the step of running a setup script
+func planScript(script Script) PlanStep {
+	step := PlanStep{Name: script.Name, Method: "script", Note: script.Description}
+	if len(script.ExecArgs) > 0 {
+		step.Method = "sh"
+		step.Commands = []string{strings.Join(script.ExecArgs, " ")}
+		step.Note = ""
+	}
+
+	if script.CheckFn != nil && script.CheckFn().Installed {
+		step.Skip = "already configured"
+	} else if script.RequiresTool != "" {
+		if t := GetToolByName(script.RequiresTool); t != nil && !t.Check().Installed {
+			step.Note = strings.TrimSpace(step.Note + " (requires " + script.RequiresTool + ")")
+		}
+	}
+	return step
+}
+
+func joinCommands(commands [][]string) []string {
+	lines := make([]string, len(commands))
+	for i, args := range commands {
+		lines[i] = strings.Join(args, " ")
+	}
+	return lines
+}
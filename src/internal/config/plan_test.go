@@ -0,0 +1,88 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestPlanInstall(t *testing.T) {
+	useAppleSilicon(t)
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "homebrew", Command: "brew", Method: InstallManual, InstallFn: func() error { return tool.Run("/bin/bash", "-c", "install.sh") }},
+		{Name: "bun", Command: "bun", Method: InstallBrewFormula, Formula: "bun", Dependencies: []string{"homebrew"}},
+		{Name: "codex", Command: "codex", Method: InstallBun, Formula: "@openai/codex", Dependencies: []string{"bun"}},
+		{Name: "xcode", Command: "xcodebuild", Method: InstallXcode},
+	}
+
+	fake := tool.NewFakeRunner().WithPath("brew")
+	t.Cleanup(tool.SetRunner(fake))
+
+	tests := []struct {
+		name     string
+		given    []string
+		expected []PlanStep
+	}{
+		{
+			name:  "dependencies first, installed ones skipped",
+			given: []string{"codex"},
+			expected: []PlanStep{
+				{Name: "homebrew", Method: "sh", Skip: "already installed"},
+				{Name: "bun", Method: "brew", Commands: []string{"arch -arm64 brew install bun"}},
+				{Name: "codex", Method: "bun", Commands: []string{"bun install -g @openai/codex"}},
+			},
+		},
+		{
+			name:  "tool without installer",
+			given: []string{"xcode"},
+			expected: []PlanStep{
+				{Name: "xcode", Method: "xcode", Note: "no automatic installer, install it manually"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanInstall(tt.given)
+			if err != nil {
+				t.Fatalf("PlanInstall() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("PlanInstall() =\n%+v\nwant\n%+v", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := PlanInstall([]string{"nope"}); err == nil {
+		t.Error("PlanInstall() should reject unknown tools")
+	}
+	// Installers are recorded, never executed: only checks reach the runner
+	for _, c := range fake.Commands() {
+		if c == "arch -arm64 brew install bun" || c == "bun install -g @openai/codex" {
+			t.Errorf("PlanInstall() executed %q", c)
+		}
+	}
+}
+
+func TestCleanableClean(t *testing.T) {
+	fake := tool.NewFakeRunner()
+	t.Cleanup(tool.SetRunner(fake))
+
+	c := Cleanable{Name: "multipass", Commands: [][]string{{"multipass", "delete", "--all"}, {"multipass", "purge"}}}
+	if err := c.Clean(); err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+
+	expected := []string{"multipass delete --all", "multipass purge"}
+	if got := fake.Commands(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Clean() ran %v, want %v", got, expected)
+	}
+
+	steps := PlanClean([]Cleanable{c})
+	if len(steps) != 1 || !reflect.DeepEqual(steps[0].Commands, expected) {
+		t.Errorf("PlanClean() = %+v", steps)
+	}
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// Platform describes the machine j runs on
+type Platform struct {
+	OS   string // runtime.GOOS: "darwin", "linux"
+	Arch string // runtime.GOARCH: "arm64", "amd64"
+
+	// SystemPackageManager is apt, dnf or pacman on Linux ("" when none or on macOS)
+	SystemPackageManager InstallMethod
+}
+
+// IsMac reports whether the platform is macOS
+func (p Platform) IsMac() bool {
+	return p.OS == "darwin"
+}
+
+// IsLinux reports whether the platform is Linux
+func (p Platform) IsLinux() bool {
+	return p.OS == "linux"
+}
+
+// linuxPackageManagers are probed in order; the first one found is used
+var linuxPackageManagers = []struct {
+	Method  InstallMethod
+	Command string
+}{
+	{InstallApt, "apt-get"},
+	{InstallDnf, "dnf"},
+	{InstallPacman, "pacman"},
+}
+
+var (
+	platformMu       sync.Mutex
+	platformDetected bool
+	platform         Platform
+)
+
+// CurrentPlatform returns the detected platform (detected once, then cached)
+func CurrentPlatform() Platform {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	if !platformDetected {
+		platform = DetectPlatform()
+		platformDetected = true
+	}
+	return platform
+}
+
+// SetPlatform overrides the detected platform and returns a func restoring it
+func SetPlatform(p Platform) (restore func()) {
+	platformMu.Lock()
+	previous, previousDetected := platform, platformDetected
+	platform, platformDetected = p, true
+	platformMu.Unlock()
+	return func() {
+		platformMu.Lock()
+		platform, platformDetected = previous, previousDetected
+		platformMu.Unlock()
+	}
+}
+
+// DetectPlatform inspects the OS, architecture and system package manager
+func DetectPlatform() Platform {
+	p := Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if p.IsLinux() {
+		for _, pm := range linuxPackageManagers {
+			if _, err := tool.LookPath(pm.Command); err == nil {
+				p.SystemPackageManager = pm.Method
+				break
+			}
+		}
+	}
+	return p
+}
+
+// ResolveForPlatform returns tools adapted to the platform. On Linux a tool
+// with a package mapping for the system package manager switches to it;
+// brew formulae stay on Homebrew (Linuxbrew); casks, Mac App Store apps and
+// Xcode tools without a mapping are marked Unsupported. On every platform,
+// release tools without an asset for it are marked Unsupported.
+func ResolveForPlatform(tools []Tool, p Platform) []Tool {
+	resolved := make([]Tool, len(tools))
+	copy(resolved, tools)
+	for i, t := range resolved {
+		if t.Method == InstallRelease && t.InstallFn == nil && (t.Release == nil || t.Release.Asset(p) == "") {
+			resolved[i].Unsupported = true
+		}
+	}
+	if p.IsMac() {
+		return resolved
+	}
+
+	for i, t := range resolved {
+		if pkg, ok := t.Linux[p.SystemPackageManager]; ok && p.SystemPackageManager != "" {
+			t.Method = p.SystemPackageManager
+			t.Formula = pkg
+			t.InstallFn = nil
+			t.UninstallFn = nil
+			t.RemoteScript = nil
+			if t.Command != "" {
+				t.CheckFn = nil // Custom checks look at macOS paths
+			}
+			t.Dependencies = withoutDependency(t.Dependencies, "homebrew")
+			resolved[i] = t
+			continue
+		}
+
+		switch t.Method {
+		case InstallBrewCask, InstallMAS, InstallXcode:
+			t.Unsupported = true
+		}
+		resolved[i] = t
+	}
+	return resolved
+}
+
+// ApplyPlatform adapts Tools to the current platform
+func ApplyPlatform() {
+	Tools = ResolveForPlatform(Tools, CurrentPlatform())
+}
+
+func withoutDependency(deps []string, name string) []string {
+	var result []string
+	for _, dep := range deps {
+		if dep != name {
+			result = append(result, dep)
+		}
+	}
+	return result
+}
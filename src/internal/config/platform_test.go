@@ -0,0 +1,104 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestResolveForPlatform(t *testing.T) {
+	builtin := []Tool{
+		{Name: "go", Command: "go", Method: InstallBrewFormula, Formula: "go", Dependencies: []string{"homebrew"},
+			Linux: map[InstallMethod]string{InstallApt: "golang-go", InstallPacman: "go"}},
+		{Name: "terraform", Command: "terraform", Method: InstallBrewFormula, Formula: "terraform", Dependencies: []string{"homebrew"}},
+		{Name: "zed", Method: InstallBrewCask, Formula: "zed"},
+		{Name: "pages", Method: InstallMAS},
+		{Name: "codex", Command: "codex", Method: InstallBun, Formula: "codex"},
+	}
+
+	tests := []struct {
+		name        string
+		platform    Platform
+		method      map[string]InstallMethod
+		unsupported []string
+	}{
+		{
+			name:     "macOS keeps the registry",
+			platform: Platform{OS: "darwin", Arch: "arm64"},
+			method:   map[string]InstallMethod{"go": InstallBrewFormula, "zed": InstallBrewCask},
+		},
+		{
+			name:        "apt mapping wins, formulae fall back to Linuxbrew",
+			platform:    Platform{OS: "linux", Arch: "amd64", SystemPackageManager: InstallApt},
+			method:      map[string]InstallMethod{"go": InstallApt, "terraform": InstallBrewFormula, "codex": InstallBun},
+			unsupported: []string{"zed", "pages"},
+		},
+		{
+			name:        "no mapping for dnf",
+			platform:    Platform{OS: "linux", Arch: "amd64", SystemPackageManager: InstallDnf},
+			method:      map[string]InstallMethod{"go": InstallBrewFormula},
+			unsupported: []string{"zed", "pages"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := ResolveForPlatform(builtin, tt.platform)
+
+			var unsupported []string
+			for _, r := range resolved {
+				if want, ok := tt.method[r.Name]; ok && r.Method != want {
+					t.Errorf("%s: Method = %s, want %s", r.Name, r.Method, want)
+				}
+				if r.Unsupported {
+					unsupported = append(unsupported, r.Name)
+				}
+			}
+			if !reflect.DeepEqual(unsupported, tt.unsupported) {
+				t.Errorf("unsupported = %v, want %v", unsupported, tt.unsupported)
+			}
+		})
+	}
+
+	apt := ResolveForPlatform(builtin, Platform{OS: "linux", SystemPackageManager: InstallApt})[0]
+	if apt.Formula != "golang-go" || len(apt.Dependencies) != 0 {
+		t.Errorf("apt go = %+v, want formula golang-go without the homebrew dependency", apt)
+	}
+	if builtin[0].Method != InstallBrewFormula {
+		t.Error("ResolveForPlatform must not mutate its input")
+	}
+}
+
+func TestLinuxInstallCommands(t *testing.T) {
+	t.Cleanup(SetPlatform(Platform{OS: "linux", Arch: "amd64", SystemPackageManager: InstallApt}))
+
+	tests := []struct {
+		name     string
+		given    Tool
+		expected string
+	}{
+		{"apt", Tool{Name: "go", Method: InstallApt, Formula: "golang-go"}, "sudo apt-get install -y golang-go"},
+		{"dnf", Tool{Name: "go", Method: InstallDnf, Formula: "golang"}, "sudo dnf install -y golang"},
+		{"pacman", Tool{Name: "gh", Method: InstallPacman, Formula: "github-cli"}, "sudo pacman -S --noconfirm github-cli"},
+		{"linuxbrew without arch", Tool{Name: "terraform", Method: InstallBrewFormula, Formula: "terraform"}, "brew install terraform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := tool.NewFakeRunner()
+			t.Cleanup(tool.SetRunner(fake))
+
+			if err := tt.given.Install(); err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+			if got := fake.Commands(); len(got) != 1 || got[0] != tt.expected {
+				t.Errorf("Install() ran %v, want [%s]", got, tt.expected)
+			}
+		})
+	}
+
+	if err := (Tool{Name: "zed", Method: InstallBrewCask, Unsupported: true}).Install(); err == nil {
+		t.Error("Install() should refuse unsupported tools")
+	}
+}
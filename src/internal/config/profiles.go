@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Profile is a named set of tools, setup scripts and favorite skills, so a
+// machine only gets what its owner works on (j install|setup|status --profile)
+type Profile struct {
+	Name        string         `json:"-"`
+	Description string         `json:"description,omitempty"`
+	Tools       []string       `json:"tools,omitempty"`      // Tool names or aliases
+	Categories  []ToolCategory `json:"categories,omitempty"` // Every tool of these categories
+	Scripts     []string       `json:"scripts,omitempty"`    // Setup script names
+	Skills      []string       `json:"skills,omitempty"`     // FavoriteSkills names
+}
+
+// Profiles are the built-in profiles. Profiles in jrc.json are added to them,
+// replacing a built-in profile of the same name.
+var Profiles = []Profile{
+	{
+		Name:        "minimal",
+		Description: "Shell, git and signing",
+		Tools:       []string{"homebrew", "git", "gh", "gpg", "ohmyzsh", "tmux"},
+		Scripts:     []string{"hushlogin", "ssh", "gpg", "gh", "tmux"},
+	},
+	{
+		Name:        "backend",
+		Description: "Runtimes, containers and infrastructure",
+		Tools:       []string{"homebrew", "git", "gh", "gpg", "tmux", "orbstack", "tailscale", "lens", "zed"},
+		Categories:  []ToolCategory{CategoryRuntimes, CategoryDevOps},
+		Scripts:     []string{"ssh", "gpg", "gh", "java", "zed"},
+		Skills:      []string{"qmd"},
+	},
+	{
+		Name:        "mobile",
+		Description: "Expo, React Native, iOS and Android",
+		Tools:       []string{"homebrew", "git", "gh", "node", "bun", "openjdk", "cocoapods", "eas", "android-studio", "xcode"},
+		Scripts:     []string{"ssh", "gh", "java"},
+		Skills:      []string{"upgrading-expo", "vercel-react-native-skills"},
+	},
+	{
+		Name:        "ai",
+		Description: "Coding agents, local models and AI apps",
+		Tools:       []string{"homebrew", "git", "gh", "node", "bun", "python", "uv", "chatgpt", "claude-desktop", "cursor"},
+		Categories:  []ToolCategory{CategoryAI},
+		Scripts:     []string{"ssh", "gh"},
+		Skills:      []string{"frontend-design", "qmd", "last30days"},
+	},
+	{
+		Name:        "media",
+		Description: "Video, audio and photo editing",
+		Tools:       []string{"mas", "final-cut-pro", "compressor", "logic-pro", "lightroom"},
+	},
+}
+
+// GetProfiles returns the built-in profiles, then the user profiles of jrc.json
+func GetProfiles() ([]Profile, error) {
+	profiles := slices.Clone(Profiles)
+	cfg, err := LoadJRC()
+	if err != nil {
+		return profiles, err
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		p.Name = name
+		p.Categories = normalizeCategories(p.Categories)
+		if i := slices.IndexFunc(profiles, func(b Profile) bool { return b.Name == name }); i >= 0 {
+			profiles[i] = p
+		} else {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+// normalizeCategories matches the categories case-insensitively, as in the
+// manifest. Unknown ones are kept for ValidateProfile to report.
+func normalizeCategories(categories []ToolCategory) []ToolCategory {
+	normalized := make([]ToolCategory, len(categories))
+	for i, c := range categories {
+		normalized[i] = c
+		if known, ok := lookupCategory(string(c)); ok {
+			normalized[i] = known
+		}
+	}
+	return normalized
+}
+
+// FindProfile returns the profile with the given name, checked against the registries
+func FindProfile(name string) (Profile, error) {
+	profiles, err := GetProfiles()
+	if err != nil {
+		return Profile{}, err
+	}
+	for _, p := range profiles {
+		if p.Name == name {
+			return p, ValidateProfile(p, Tools, Scripts)
+		}
+	}
+
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+	return Profile{}, fmt.Errorf("%w (profiles: %s)", UnknownError("profile", name), strings.Join(names, ", "))
+}
+
+// ValidateProfile reports the tools, categories, scripts and skills of the
+// profile that do not exist, all together, one per line
+func ValidateProfile(p Profile, tools []Tool, scripts []Script) error {
+	var errs []error
+	fail := func(kind, name string) {
+		errs = append(errs, fmt.Errorf("profile %q: unknown %s %q", p.Name, kind, name))
+	}
+	for _, name := range p.Tools {
+		if findToolIn(tools, name) == nil {
+			fail("tool", name)
+		}
+	}
+	for _, category := range p.Categories {
+		if !slices.Contains(ToolCategories, category) {
+			fail("category", string(category))
+		}
+	}
+	for _, name := range p.Scripts {
+		if !slices.ContainsFunc(scripts, func(s Script) bool { return s.Name == name }) {
+			fail("script", name)
+		}
+	}
+	for _, name := range p.Skills {
+		if !slices.ContainsFunc(FavoriteSkills, func(s Skill) bool { return s.Skill == name }) {
+			fail("skill", name)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// findToolIn returns the tool of tools with the given name or alias
+func findToolIn(tools []Tool, name string) *Tool {
+	for i := range tools {
+		if tools[i].Name == name || slices.Contains(tools[i].Aliases, name) {
+			return &tools[i]
+		}
+	}
+	return nil
+}
+
+// IncludesTool reports whether the tool is in the profile, by name, alias or category
+func (p Profile) IncludesTool(t Tool) bool {
+	if slices.Contains(p.Categories, t.Category) || slices.Contains(p.Tools, t.Name) {
+		return true
+	}
+	return slices.ContainsFunc(t.Aliases, func(alias string) bool { return slices.Contains(p.Tools, alias) })
+}
+
+// IncludesScript reports whether the setup script is in the profile
+func (p Profile) IncludesScript(name string) bool {
+	return slices.Contains(p.Scripts, name)
+}
+
+// ToolNames returns the tools of the profile supported on this platform, in registry order
+func (p Profile) ToolNames() []string {
+	var names []string
+	for _, t := range Tools {
+		if !t.Unsupported && p.IncludesTool(t) {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// FavoriteSkills returns the favorite skills of the profile
+func (p Profile) FavoriteSkills() []Skill {
+	var skills []Skill
+	for _, s := range FavoriteSkills {
+		if slices.Contains(p.Skills, s.Skill) {
+			skills = append(skills, s)
+		}
+	}
+	return skills
+}
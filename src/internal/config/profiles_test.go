@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestProfiles keeps the built-in profiles consistent with the registries
+func TestProfiles(t *testing.T) {
+	for _, p := range Profiles {
+		if err := ValidateProfile(p, Tools, Scripts); err != nil {
+			t.Errorf("invalid profile:\n%v", err)
+		}
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	tools := []Tool{{Name: "go", Aliases: []string{"golang"}, Category: CategoryRuntimes}}
+	scripts := []Script{{Name: "java"}}
+
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr string
+	}{
+		{"valid", Profile{Name: "p", Tools: []string{"golang"}, Categories: []ToolCategory{CategoryAI}, Scripts: []string{"java"}, Skills: []string{"qmd"}}, ""},
+		{"unknown tool", Profile{Name: "p", Tools: []string{"rust"}}, `profile "p": unknown tool "rust"`},
+		{"unknown category", Profile{Name: "p", Categories: []ToolCategory{"Games"}}, `profile "p": unknown category "Games"`},
+		{"unknown script", Profile{Name: "p", Scripts: []string{"dns"}}, `profile "p": unknown script "dns"`},
+		{"unknown skill", Profile{Name: "p", Skills: []string{"nope"}}, `profile "p": unknown skill "nope"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProfile(tt.profile, tools, scripts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateProfile() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateProfile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfileIncludes(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "go", Category: CategoryRuntimes},
+		{Name: "homebrew", Aliases: []string{"brew"}, Category: CategoryPackageManager},
+		{Name: "claude", Category: CategoryAI},
+		{Name: "zed", Category: CategoryGUIApps, Unsupported: true},
+	}
+
+	p := Profile{Tools: []string{"brew", "zed"}, Categories: []ToolCategory{CategoryAI}, Scripts: []string{"java"}}
+	if got, expected := p.ToolNames(), []string{"homebrew", "claude"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("ToolNames() = %v, want %v", got, expected)
+	}
+	if !p.IncludesScript("java") || p.IncludesScript("dns") {
+		t.Error("IncludesScript() should only match the scripts of the profile")
+	}
+}
+
+func TestFindProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	jrc := `{
+  "profiles": {
+    "media": {"description": "Just video", "tools": ["final-cut-pro"]},
+    "design": {"tools": ["zed"], "skills": ["frontend-design"]},
+    "broken": {"tools": ["photoshop"]},
+    "agents": {"categories": ["ai", "devops"]},
+    "typo": {"categories": ["gaming"]}
+  }
+}`
+	if err := os.MkdirAll(filepath.Dir(jrcPath()), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jrcPath(), []byte(jrc), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	media, err := FindProfile("media")
+	if err != nil || media.Description != "Just video" || !reflect.DeepEqual(media.Tools, []string{"final-cut-pro"}) {
+		t.Errorf("FindProfile(media) = %+v, %v, want the jrc.json override", media, err)
+	}
+	design, err := FindProfile("design")
+	if err != nil || design.Name != "design" || len(design.FavoriteSkills()) != 1 {
+		t.Errorf("FindProfile(design) = %+v, %v, want the jrc.json profile", design, err)
+	}
+	if _, err := FindProfile("backend"); err != nil {
+		t.Errorf("FindProfile(backend) error = %v", err)
+	}
+	if _, err := FindProfile("broken"); err == nil || !strings.Contains(err.Error(), `unknown tool "photoshop"`) {
+		t.Errorf("FindProfile(broken) error = %v, want unknown tool", err)
+	}
+	agents, err := FindProfile("agents")
+	if err != nil || !reflect.DeepEqual(agents.Categories, []ToolCategory{CategoryAI, CategoryDevOps}) {
+		t.Errorf("FindProfile(agents) = %+v, %v, want the categories matched case-insensitively", agents, err)
+	}
+	if _, err := FindProfile("typo"); err == nil || !strings.Contains(err.Error(), `unknown category "gaming"`) {
+		t.Errorf("FindProfile(typo) error = %v, want unknown category", err)
+	}
+	if _, err := FindProfile("gaming"); !errors.Is(err, ErrUnknownItem) {
+		t.Errorf("FindProfile(gaming) error = %v, want ErrUnknownItem", err)
+	}
+}
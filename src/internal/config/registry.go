@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidateRegistry checks the tool and script registries for mistakes the
+// lookups would otherwise hide: duplicate names or aliases, unknown dependencies,
+// dependency cycles, dangling script references and tools that cannot be checked.
+// All problems are reported together, one per line.
+func ValidateRegistry(tools []Tool, scripts []Script) error {
+	var errs []error
+	fail := func(kind, name, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s %q: %s", kind, name, fmt.Sprintf(format, args...)))
+	}
+
+	toolsByName := make(map[string]Tool, len(tools))
+	for _, t := range tools {
+		if _, dup := toolsByName[t.Name]; dup {
+			fail("tool", t.Name, "duplicate name")
+			continue
+		}
+		toolsByName[t.Name] = t
+	}
+
+	aliasOwners := make(map[string]string)
+	for _, t := range tools {
+		for _, alias := range t.Aliases {
+			if _, taken := toolsByName[alias]; taken {
+				fail("tool", t.Name, "alias %q is the name of a tool", alias)
+			} else if owner, taken := aliasOwners[alias]; taken && owner != t.Name {
+				fail("tool", t.Name, "alias %q is already used by %q", alias, owner)
+			}
+			aliasOwners[alias] = t.Name
+		}
+	}
+
+	scriptNames := make(map[string]bool, len(scripts))
+	for _, s := range scripts {
+		if scriptNames[s.Name] {
+			fail("script", s.Name, "duplicate name")
+		}
+		scriptNames[s.Name] = true
+	}
+
+	for _, t := range tools {
+		for _, dep := range t.Dependencies {
+			if dep == t.Name {
+				fail("tool", t.Name, "depends on itself")
+			} else if _, ok := toolsByName[dep]; !ok {
+				fail("tool", t.Name, "unknown dependency %q", dep)
+			}
+		}
+		for _, script := range t.Scripts {
+			if !scriptNames[script] {
+				fail("tool", t.Name, "unknown script %q", script)
+			}
+		}
+		if t.CheckFn == nil && t.Command == "" && t.Method != InstallBrewFormula && t.Method != InstallBrewCask && t.AppStoreID == 0 && t.App == "" {
+			fail("tool", t.Name, "no check method (set Command, CheckFn, AppStoreID, App, or a brew/cask Method)")
+		}
+		if t.MinVersion != "" {
+			if _, err := t.VersionConstraint(); err != nil {
+				fail("tool", t.Name, "%v", err)
+			}
+		}
+		if t.Method == InstallRelease && t.InstallFn == nil {
+			if r := t.Release; r == nil || r.URL == "" || len(r.Assets) == 0 || len(r.Binaries) == 0 {
+				fail("tool", t.Name, "release method needs Release.URL, Assets and Binaries")
+			} else if len(r.SHA256) == 0 && r.ChecksumURL == "" {
+				fail("tool", t.Name, "release has no checksum (set Release.SHA256 or ChecksumURL)")
+			}
+		}
+	}
+
+	for _, s := range scripts {
+		if s.RequiresTool == "" {
+			continue
+		}
+		if _, ok := toolsByName[s.RequiresTool]; !ok {
+			fail("script", s.Name, "requires unknown tool %q", s.RequiresTool)
+		}
+	}
+
+	for _, cycle := range findDependencyCycles(tools, toolsByName) {
+		fail("tool", cycle[0], "dependency cycle %s", strings.Join(cycle, " -> "))
+	}
+
+	return errors.Join(errs...)
+}
+
+// findDependencyCycles returns each dependency cycle once, as the path from
+// its first tool in registry order back to itself
+func findDependencyCycles(tools []Tool, toolsByName map[string]Tool) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(tools))
+	var stack []string
+	var cycles [][]string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+
+		for _, dep := range toolsByName[name].Dependencies {
+			if dep == name {
+				continue // Reported as "depends on itself"
+			}
+			if _, ok := toolsByName[dep]; !ok {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				for i := range stack {
+					if stack[i] == dep {
+						cycle := append([]string{}, stack[i:]...)
+						cycles = append(cycles, append(cycle, dep))
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+
+	for _, t := range tools {
+		if state[t.Name] == unvisited {
+			visit(t.Name)
+		}
+	}
+	return cycles
+}
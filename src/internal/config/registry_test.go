@@ -0,0 +1,120 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRegistry keeps the built-in registries consistent
+func TestRegistry(t *testing.T) {
+	if err := ValidateRegistry(Tools, Scripts); err != nil {
+		t.Fatalf("invalid registry:\n%v", err)
+	}
+}
+
+func TestValidateRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		tools   []Tool
+		scripts []Script
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			tools: []Tool{{Name: "homebrew", Command: "brew"}, {Name: "go", Method: InstallBrewFormula, Dependencies: []string{"homebrew"}}},
+		},
+		{
+			name:    "duplicate tool",
+			tools:   []Tool{{Name: "go", Command: "go"}, {Name: "go", Command: "go"}},
+			wantErr: `tool "go": duplicate name`,
+		},
+		{
+			name:    "duplicate script",
+			scripts: []Script{{Name: "java"}, {Name: "java"}},
+			wantErr: `script "java": duplicate name`,
+		},
+		{
+			name:    "unknown dependency",
+			tools:   []Tool{{Name: "go", Command: "go", Dependencies: []string{"homebrw"}}},
+			wantErr: `tool "go": unknown dependency "homebrw"`,
+		},
+		{
+			name: "cycle",
+			tools: []Tool{
+				{Name: "a", Command: "a", Dependencies: []string{"b"}},
+				{Name: "b", Command: "b", Dependencies: []string{"c"}},
+				{Name: "c", Command: "c", Dependencies: []string{"a"}},
+			},
+			wantErr: `tool "a": dependency cycle a -> b -> c -> a`,
+		},
+		{
+			name:    "self dependency",
+			tools:   []Tool{{Name: "a", Command: "a", Dependencies: []string{"a"}}},
+			wantErr: `tool "a": depends on itself`,
+		},
+		{
+			name:    "dangling tool script",
+			tools:   []Tool{{Name: "openjdk", Command: "java", Scripts: []string{"jav"}}},
+			wantErr: `tool "openjdk": unknown script "jav"`,
+		},
+		{
+			name:    "script requires unknown tool",
+			scripts: []Script{{Name: "java", RequiresTool: "jdk"}},
+			wantErr: `script "java": requires unknown tool "jdk"`,
+		},
+		{
+			name:    "no check method",
+			tools:   []Tool{{Name: "xcode", Method: InstallXcode}},
+			wantErr: `tool "xcode": no check method`,
+		},
+		{
+			name:    "release without checksum",
+			tools:   []Tool{{Name: "tool", Command: "tool", Method: InstallRelease, Release: &Release{URL: "https://example.com/{asset}", Assets: map[string]string{"darwin/arm64": "tool.tar.gz"}, Binaries: []string{"tool"}}}},
+			wantErr: `tool "tool": release has no checksum`,
+		},
+		{
+			name:    "alias shadowing a tool",
+			tools:   []Tool{{Name: "go", Command: "go"}, {Name: "golang", Command: "go", Aliases: []string{"go"}}},
+			wantErr: `tool "golang": alias "go" is the name of a tool`,
+		},
+		{
+			name:    "duplicate alias",
+			tools:   []Tool{{Name: "orbstack", Command: "orb", Aliases: []string{"docker"}}, {Name: "docker-desktop", Command: "docker", Aliases: []string{"docker"}}},
+			wantErr: `tool "docker-desktop": alias "docker" is already used by "orbstack"`,
+		},
+		{
+			name:    "invalid min version",
+			tools:   []Tool{{Name: "go", Command: "go", MinVersion: ">=latest"}},
+			wantErr: `tool "go": invalid version constraint`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRegistry(tt.tools, tt.scripts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateRegistry() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateRegistry() err=%v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetDependencyOrderWithCycle(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "a", Command: "a", Dependencies: []string{"b"}},
+		{Name: "b", Command: "b", Dependencies: []string{"a"}},
+	}
+
+	got := GetDependencyOrder([]string{"a"})
+	if len(got) != 2 || got[0].Name != "b" || got[1].Name != "a" {
+		t.Errorf("GetDependencyOrder() = %v, want [b a]", got)
+	}
+}
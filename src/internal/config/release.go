@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// Release describes a tool shipped as release archives (e.g. GitHub releases).
+// URL, ChecksumURL, Assets and Binaries may use the {version}, {os} and {arch}
+// placeholders; URL and ChecksumURL may also use {asset}.
+type Release struct {
+	Version     string            // Release to install
+	URL         string            // Archive URL, e.g. https://github.com/o/r/releases/download/v{version}/{asset}
+	Assets      map[string]string // Archive name per "os/arch", e.g. "darwin/arm64": "tool_{version}_darwin_arm64.tar.gz"
+	SHA256      map[string]string // Archive checksum per "os/arch"
+	ChecksumURL string            // sha256sum-style file listing the assets, used when SHA256 has no entry
+	Binaries    []string          // Paths of the binaries inside the archive
+}
+
+// ReleaseBinDir is where release binaries are installed (add it to PATH)
+func ReleaseBinDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".local", "share", "jterrazz", "bin")
+}
+
+// Asset returns the archive name for the platform ("" when none is published)
+func (r Release) Asset(p Platform) string {
+	return r.expand(r.Assets[p.OS+"/"+p.Arch], p, "")
+}
+
+// AssetURL returns the download URL of the platform archive
+func (r Release) AssetURL(p Platform) string {
+	return r.expand(r.URL, p, r.Asset(p))
+}
+
+func (r Release) expand(s string, p Platform, asset string) string {
+	return strings.NewReplacer(
+		"{version}", r.Version,
+		"{os}", p.OS,
+		"{arch}", p.Arch,
+		"{asset}", asset,
+	).Replace(s)
+}
+
+// installRelease downloads the platform archive to a temp dir, verifies its
+// checksum and extracts the binaries into ReleaseBinDir
+func (t Tool) installRelease() error {
+	if t.Release == nil {
+		return fmt.Errorf("%s has no release to install", t.Name)
+	}
+	r := *t.Release
+	p := CurrentPlatform()
+	asset := r.Asset(p)
+	if asset == "" {
+		return fmt.Errorf("%s publishes no release for %s/%s", t.Name, p.OS, p.Arch)
+	}
+
+	tmp, err := os.MkdirTemp("", "j-release-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	archive := filepath.Join(tmp, path.Base(asset))
+	if err := tool.DownloadFile(r.AssetURL(p), archive); err != nil {
+		return err
+	}
+
+	expected, err := r.checksum(p, asset, tmp)
+	if err != nil {
+		return err
+	}
+	actual, err := tool.FileSHA256(archive)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset, expected, actual)
+	}
+
+	binaries := make([]string, len(r.Binaries))
+	for i, b := range r.Binaries {
+		binaries[i] = r.expand(b, p, asset)
+	}
+	if err := os.MkdirAll(ReleaseBinDir(), 0755); err != nil {
+		return err
+	}
+	return tool.ExtractFiles(archive, binaries, ReleaseBinDir())
+}
+
+// checksum returns the expected SHA-256 of the asset, from SHA256 or the checksum file
+func (r Release) checksum(p Platform, asset, tmp string) (string, error) {
+	if sum := r.SHA256[p.OS+"/"+p.Arch]; sum != "" {
+		return sum, nil
+	}
+	if r.ChecksumURL == "" {
+		return "", fmt.Errorf("no checksum to verify %s (set SHA256 or ChecksumURL)", asset)
+	}
+
+	sums := filepath.Join(tmp, "checksums.txt")
+	if err := tool.DownloadFile(r.expand(r.ChecksumURL, p, asset), sums); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(sums)
+	if err != nil {
+		return "", err
+	}
+	sum, ok := tool.ParseChecksumFile(string(data), asset)
+	if !ok {
+		return "", fmt.Errorf("%s is not listed in the checksum file", asset)
+	}
+	return sum, nil
+}
+
+// uninstallRelease removes the binaries installed by installRelease
+func (t Tool) uninstallRelease() error {
+	if t.Release == nil {
+		return fmt.Errorf("%s has no release to uninstall", t.Name)
+	}
+	p := CurrentPlatform()
+	for _, b := range t.Release.Binaries {
+		target := filepath.Join(ReleaseBinDir(), path.Base(t.Release.expand(b, p, t.Release.Asset(p))))
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseBinary returns the path of the tool command in ReleaseBinDir, if present
+func (t Tool) releaseBinary() (string, bool) {
+	if t.Method != InstallRelease || t.Command == "" {
+		return "", false
+	}
+	target := filepath.Join(ReleaseBinDir(), t.Command)
+	if _, err := os.Stat(target); err != nil {
+		return "", false
+	}
+	return target, true
+}
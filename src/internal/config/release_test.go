@@ -0,0 +1,144 @@
+package config
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// releaseArchives builds a tar.gz and a zip holding bin/tool, served by a local HTTP stand-in
+func releaseArchives(t *testing.T) (server *httptest.Server, sums map[string]string) {
+	t.Helper()
+	const binary = "#!/bin/sh\necho tool 1.2.0\n"
+
+	var tgz bytes.Buffer
+	gz := gzip.NewWriter(&tgz)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "tool_1.2.0/README.md", Mode: 0644, Size: 2, Typeflag: tar.TypeReg})
+	tw.Write([]byte("hi"))
+	tw.WriteHeader(&tar.Header{Name: "tool_1.2.0/bin/tool", Mode: 0755, Size: int64(len(binary)), Typeflag: tar.TypeReg})
+	tw.Write([]byte(binary))
+	tw.Close()
+	gz.Close()
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	w, _ := zw.Create("bin/tool")
+	w.Write([]byte(binary))
+	zw.Close()
+
+	files := map[string][]byte{
+		"/v1.2.0/tool_1.2.0_darwin_arm64.tar.gz": tgz.Bytes(),
+		"/v1.2.0/tool_1.2.0_linux_amd64.zip":     zipped.Bytes(),
+	}
+	sums = make(map[string]string)
+	var checksums strings.Builder
+	for name, data := range files {
+		sum := sha256.Sum256(data)
+		sums[filepath.Base(name)] = hex.EncodeToString(sum[:])
+		checksums.WriteString(hex.EncodeToString(sum[:]) + "  " + filepath.Base(name) + "\n")
+	}
+	files["/v1.2.0/checksums.txt"] = []byte(checksums.String())
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server, sums
+}
+
+func TestInstallRelease(t *testing.T) {
+	server, sums := releaseArchives(t)
+	release := func() *Release {
+		return &Release{
+			Version: "1.2.0",
+			URL:     server.URL + "/v{version}/{asset}",
+			Assets: map[string]string{
+				"darwin/arm64": "tool_{version}_darwin_arm64.tar.gz",
+				"linux/amd64":  "tool_{version}_linux_amd64.zip",
+			},
+			Binaries: []string{"tool_{version}/bin/tool"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		platform Platform
+		given    func(r *Release)
+		wantErr  string
+	}{
+		{"tar.gz with pinned checksum", Platform{OS: "darwin", Arch: "arm64"}, func(r *Release) {
+			r.SHA256 = map[string]string{"darwin/arm64": sums["tool_1.2.0_darwin_arm64.tar.gz"]}
+		}, ""},
+		{"zip with checksum file", Platform{OS: "linux", Arch: "amd64"}, func(r *Release) {
+			r.ChecksumURL = server.URL + "/v{version}/checksums.txt"
+			r.Binaries = []string{"bin/tool"}
+		}, ""},
+		{"checksum mismatch", Platform{OS: "darwin", Arch: "arm64"}, func(r *Release) {
+			r.SHA256 = map[string]string{"darwin/arm64": strings.Repeat("0", 64)}
+		}, "checksum mismatch"},
+		{"no checksum", Platform{OS: "darwin", Arch: "arm64"}, func(r *Release) {}, "no checksum"},
+		{"missing binary", Platform{OS: "darwin", Arch: "arm64"}, func(r *Release) {
+			r.SHA256 = map[string]string{"darwin/arm64": sums["tool_1.2.0_darwin_arm64.tar.gz"]}
+			r.Binaries = []string{"tool"}
+		}, "tool not found"},
+		{"no asset for platform", Platform{OS: "linux", Arch: "arm64"}, func(r *Release) {}, "no release for linux/arm64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Cleanup(SetPlatform(tt.platform))
+
+			r := release()
+			tt.given(r)
+			tl := Tool{Name: "tool", Command: "tool", Method: InstallRelease, Release: r}
+
+			err := tl.Install()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Install() error = %v, want containing %q", err, tt.wantErr)
+				}
+				if _, statErr := os.Stat(filepath.Join(ReleaseBinDir(), "tool")); statErr == nil {
+					t.Error("the binary should not be installed after a failure")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+
+			info, err := os.Stat(filepath.Join(ReleaseBinDir(), "tool"))
+			if err != nil {
+				t.Fatalf("binary not installed: %v", err)
+			}
+			if info.Mode().Perm()&0100 == 0 {
+				t.Errorf("binary mode = %v, want executable", info.Mode())
+			}
+			if !tl.Check().Installed {
+				t.Error("Check() should find the binary in ReleaseBinDir")
+			}
+
+			if err := tl.Uninstall(); err != nil {
+				t.Fatalf("Uninstall() error = %v", err)
+			}
+			if tl.Check().Installed {
+				t.Error("Check() should not find the binary after Uninstall()")
+			}
+		})
+	}
+}
@@ -7,13 +7,14 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 )
 
 // RemoteMode controls how the local tailscale client is run.
@@ -49,7 +50,8 @@ type RemoteSettings struct {
 
 // JRCConfig is the user runtime config persisted in ~/.config/jterrazz/jrc.json.
 type JRCConfig struct {
-	Remote RemoteSettings `json:"remote"`
+	Remote   RemoteSettings     `json:"remote"`
+	Profiles map[string]Profile `json:"profiles,omitempty"` // Install profiles, by name
 }
 
 // RemoteStatus summarizes current remote connectivity.
@@ -296,12 +298,14 @@ func tailscaleArgsForMode(mode RemoteMode, args ...string) []string {
 
 func runTailscale(mode RemoteMode, args ...string) (string, error) {
 	allArgs := tailscaleArgsForMode(mode, args...)
-	cmd := exec.Command("tailscale", allArgs...)
 	var output bytes.Buffer
-	cmd.Stdout = io.MultiWriter(os.Stdout, &output)
-	cmd.Stderr = io.MultiWriter(os.Stderr, &output)
-	cmd.Stdin = os.Stdin
-	err := cmd.Run()
+	err := tool.RunCmd(tool.Cmd{
+		Name:   "tailscale",
+		Args:   allArgs,
+		Stdin:  os.Stdin,
+		Stdout: io.MultiWriter(os.Stdout, &output),
+		Stderr: io.MultiWriter(os.Stderr, &output),
+	})
 	return output.String(), err
 }
 
@@ -415,8 +419,7 @@ func mergeUpArgsWithSuggestedFlags(desiredUpArgs []string, suggestedFlags []stri
 
 func getTailscaleStatus(mode RemoteMode) (tailscaleStatus, error) {
 	var st tailscaleStatus
-	cmd := exec.Command("tailscale", tailscaleArgsForMode(mode, "status", "--json")...)
-	out, err := cmd.Output()
+	out, err := tool.Output("tailscale", tailscaleArgsForMode(mode, "status", "--json")...)
 	if err != nil {
 		return st, err
 	}
@@ -445,22 +448,21 @@ func ensureUserspaceDaemon() error {
 	}
 	defer logFile.Close()
 
-	cmd := exec.Command(
-		"tailscaled",
-		"--tun=userspace-networking",
-		"--state="+userspaceStatePath(),
-		"--socket="+userspaceSocketPath(),
-	)
-	cmd.Stdout = logFile
-	cmd.Stderr = logFile
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
-
-	if err := cmd.Start(); err != nil {
+	pid, err := tool.Start(tool.Cmd{
+		Name: "tailscaled",
+		Args: []string{
+			"--tun=userspace-networking",
+			"--state=" + userspaceStatePath(),
+			"--socket=" + userspaceSocketPath(),
+		},
+		Stdout: logFile,
+		Stderr: logFile,
+	})
+	if err != nil {
 		return fmt.Errorf("failed to start userspace tailscaled: %w", err)
 	}
 
-	_ = os.WriteFile(userspacePIDPath(), []byte(strconv.Itoa(cmd.Process.Pid)), 0600)
-	_ = cmd.Process.Release()
+	_ = os.WriteFile(userspacePIDPath(), []byte(strconv.Itoa(pid)), 0600)
 
 	deadline := time.Now().Add(4 * time.Second)
 	for time.Now().Before(deadline) {
@@ -533,27 +535,16 @@ func ensureKeepAwake() error {
 		return fmt.Errorf("failed to create userspace directory: %w", err)
 	}
 
-	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0600)
+	// Output is discarded (nil writers go to the null device)
+	pid, err := tool.Start(tool.Cmd{Name: "caffeinate", Args: []string{"-i"}})
 	if err != nil {
-		return fmt.Errorf("failed to open %s: %w", os.DevNull, err)
-	}
-	defer devNull.Close()
-
-	cmd := exec.Command("caffeinate", "-i")
-	cmd.Stdout = devNull
-	cmd.Stderr = devNull
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
-
-	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start caffeinate: %w", err)
 	}
 
-	if err := os.WriteFile(keepAwakePIDPath(), []byte(strconv.Itoa(cmd.Process.Pid)), 0600); err != nil {
-		_ = cmd.Process.Signal(syscall.SIGTERM)
+	if err := os.WriteFile(keepAwakePIDPath(), []byte(strconv.Itoa(pid)), 0600); err != nil {
+		_ = syscall.Kill(pid, syscall.SIGTERM)
 		return fmt.Errorf("failed to persist caffeinate pid: %w", err)
 	}
-
-	_ = cmd.Process.Release()
 	return nil
 }
 
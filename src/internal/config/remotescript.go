@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// RemoteScript is an installer script published at a URL (the curl | bash
+// kind). It is downloaded to a file and checked against SHA256 before it runs.
+type RemoteScript struct {
+	URL    string
+	SHA256 string   // Checksum of the reviewed script ("" = not pinned, every run asks)
+	Shell  []string // Interpreter the script path is passed to, e.g. {"/bin/bash"}
+}
+
+// ScriptReview describes a downloaded installer script awaiting approval
+type ScriptReview struct {
+	Tool    string
+	URL     string
+	Path    string // Downloaded copy
+	Content []byte
+	SHA256  string // Checksum of the downloaded copy
+	Pinned  string // Checksum pinned in the registry ("" = not pinned)
+	Cached  bool   // A previously approved copy exists
+	Diff    string // Unified diff against the last approved copy ("" = none cached or identical)
+}
+
+// Verified reports whether the script matches its pinned checksum
+func (r ScriptReview) Verified() bool {
+	return r.Pinned != "" && strings.EqualFold(r.SHA256, r.Pinned)
+}
+
+// ApproveScript is asked before running a script that does not match its
+// pinned checksum. The commands set it to a terminal prompt; nil refuses.
+var ApproveScript func(ScriptReview) bool
+
+// approvedScriptsDir keeps the last approved copy of each script to diff new ones against
+func approvedScriptsDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".cache", "jterrazz", "installers")
+}
+
+// ReviewInstallScript downloads the tool installer script without running it.
+// Call cleanup once done with the review.
+func (t Tool) ReviewInstallScript() (review ScriptReview, cleanup func(), err error) {
+	cleanup = func() {}
+	if t.RemoteScript == nil {
+		return review, cleanup, fmt.Errorf("%s has no installer script", t.Name)
+	}
+
+	tmp, err := os.MkdirTemp("", "j-script-")
+	if err != nil {
+		return review, cleanup, err
+	}
+	cleanup = func() { os.RemoveAll(tmp) }
+
+	review = ScriptReview{
+		Tool:   t.Name,
+		URL:    t.RemoteScript.URL,
+		Path:   filepath.Join(tmp, t.Name+".sh"),
+		Pinned: t.RemoteScript.SHA256,
+	}
+	if err := tool.DownloadFile(review.URL, review.Path); err != nil {
+		return review, cleanup, err
+	}
+	if review.Content, err = os.ReadFile(review.Path); err != nil {
+		return review, cleanup, err
+	}
+	if review.SHA256, err = tool.FileSHA256(review.Path); err != nil {
+		return review, cleanup, err
+	}
+
+	approved := filepath.Join(approvedScriptsDir(), t.Name+".sh")
+	if _, err := os.Stat(approved); err == nil {
+		review.Cached = true
+		// diff exits 1 when the files differ
+		out, _ := tool.Output("diff", "-u", approved, review.Path)
+		review.Diff = string(out)
+	}
+	return review, cleanup, nil
+}
+
+// runRemoteScript downloads the installer, asks ApproveScript unless it
+// matches the pinned checksum, then runs it with the terminal attached
+func (t Tool) runRemoteScript() error {
+	review, cleanup, err := t.ReviewInstallScript()
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	if !review.Verified() && (ApproveScript == nil || !ApproveScript(review)) {
+		return AbortedError(t.Name + " installer script not approved")
+	}
+	if err := saveApprovedScript(t.Name, review.Content); err != nil {
+		return err
+	}
+
+	shell := t.RemoteScript.Shell
+	if len(shell) == 0 {
+		shell = []string{"/bin/bash"}
+	}
+	args := append(append([]string{}, shell[1:]...), review.Path)
+	return tool.RunLoggedInteractive(shell[0], args...)
+}
+
+func saveApprovedScript(name string, content []byte) error {
+	if err := os.MkdirAll(approvedScriptsDir(), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(approvedScriptsDir(), name+".sh"), content, 0644)
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestRunRemoteScript(t *testing.T) {
+	const script = "#!/bin/bash\necho installing\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(script))
+	}))
+	t.Cleanup(server.Close)
+	sum := sha256.Sum256([]byte(script))
+	pinned := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name        string
+		pinned      string
+		approve     *bool // nil = no prompt available
+		expectedRun bool
+		wantAsked   bool
+	}{
+		{"pinned match runs without asking", pinned, nil, true, false},
+		{"unpinned asks and runs when approved", "", ptr(true), true, true},
+		{"mismatch asks and stops when refused", strings.Repeat("0", 64), ptr(false), false, true},
+		{"mismatch without prompt refuses", strings.Repeat("0", 64), nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			fake := tool.NewFakeRunner()
+			t.Cleanup(tool.SetRunner(fake))
+
+			asked := false
+			previous := ApproveScript
+			t.Cleanup(func() { ApproveScript = previous })
+			ApproveScript = nil
+			if tt.approve != nil {
+				ApproveScript = func(r ScriptReview) bool {
+					asked = true
+					if r.SHA256 != pinned || string(r.Content) != script {
+						t.Errorf("review = %+v, want the downloaded script", r)
+					}
+					return *tt.approve
+				}
+			}
+
+			tl := Tool{Name: "brew", RemoteScript: &RemoteScript{URL: server.URL + "/install.sh", SHA256: tt.pinned, Shell: []string{"/bin/bash"}}}
+			err := tl.Install()
+
+			if asked != tt.wantAsked {
+				t.Errorf("asked = %v, want %v", asked, tt.wantAsked)
+			}
+			ran := false
+			for _, c := range fake.Commands() {
+				ran = ran || strings.HasPrefix(c, "/bin/bash ")
+			}
+			if ran != tt.expectedRun {
+				t.Errorf("ran = %v (%v), want %v", ran, fake.Commands(), tt.expectedRun)
+			}
+			if tt.expectedRun && err != nil {
+				t.Errorf("Install() error = %v", err)
+			}
+			if !tt.expectedRun && err == nil {
+				t.Error("Install() should fail when the script is not approved")
+			}
+
+			_, cacheErr := os.Stat(filepath.Join(approvedScriptsDir(), "brew.sh"))
+			if (cacheErr == nil) != tt.expectedRun {
+				t.Errorf("approved copy cached = %v, want %v", cacheErr == nil, tt.expectedRun)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
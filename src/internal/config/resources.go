@@ -3,7 +3,6 @@ package config
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -42,7 +41,7 @@ var NetworkChecks = []ResourceCheck{
 	{
 		Name: "local ip",
 		CheckFn: func() ResourceResult {
-			out, _ := exec.Command("ipconfig", "getifaddr", "en0").Output()
+			out, _ := tool.Output("ipconfig", "getifaddr", "en0")
 			ip := strings.TrimSpace(string(out))
 			if ip != "" {
 				return ResourceResult{Value: ip, Style: "muted", Available: true}
@@ -53,8 +52,7 @@ var NetworkChecks = []ResourceCheck{
 	{
 		Name: "public ip",
 		CheckFn: func() ResourceResult {
-			cmd := exec.Command("curl", "-s", "--max-time", "2", "-4", "ifconfig.me")
-			out, err := cmd.Output()
+			out, err := tool.Output("curl", "-s", "--max-time", "2", "-4", "ifconfig.me")
 			if err == nil {
 				ip := strings.TrimSpace(string(out))
 				if ip != "" {
@@ -90,7 +88,7 @@ var NetworkChecks = []ResourceCheck{
 			}
 
 			// Check if tailscale is running
-			out, err := exec.Command("tailscale", "status", "--json").Output()
+			out, err := tool.Output("tailscale", "status", "--json")
 			if err != nil {
 				return ResourceResult{Available: false}
 			}
@@ -98,7 +96,7 @@ var NetworkChecks = []ResourceCheck{
 			// Check if BackendState is "Running"
 			if strings.Contains(outStr, `"BackendState":"Running"`) {
 				// Get tailscale IP
-				ipOut, _ := exec.Command("tailscale", "ip", "-4").Output()
+				ipOut, _ := tool.Output("tailscale", "ip", "-4")
 				ip := strings.TrimSpace(string(ipOut))
 				if ip != "" {
 					return ResourceResult{Value: ip, Style: "success", Available: true}
@@ -111,7 +109,7 @@ var NetworkChecks = []ResourceCheck{
 	{
 		Name: "vpn",
 		CheckFn: func() ResourceResult {
-			out, _ := exec.Command("scutil", "--nc", "list").Output()
+			out, _ := tool.Output("scutil", "--nc", "list")
 			lines := strings.Split(string(out), "\n")
 			for _, line := range lines {
 				if strings.Contains(line, "(Connected)") {
@@ -133,7 +131,7 @@ var NetworkChecks = []ResourceCheck{
 	{
 		Name: "dns",
 		CheckFn: func() ResourceResult {
-			out, _ := exec.Command("scutil", "--dns").Output()
+			out, _ := tool.Output("scutil", "--dns")
 			var servers []string
 			for _, line := range strings.Split(string(out), "\n") {
 				if strings.Contains(line, "nameserver[") {
@@ -172,7 +170,7 @@ var NetworkChecks = []ResourceCheck{
 	{
 		Name: "listening",
 		CheckFn: func() ResourceResult {
-			out, _ := exec.Command("lsof", "-iTCP", "-sTCP:LISTEN", "-P", "-n").Output()
+			out, _ := tool.Output("lsof", "-iTCP", "-sTCP:LISTEN", "-P", "-n")
 			lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 			if len(lines) <= 1 {
 				return ResourceResult{Value: "none", Style: "muted", Available: true}
@@ -244,7 +242,7 @@ var CacheChecks = []DiskCheck{
 			if !CommandExists("docker") {
 				return ResourceResult{Available: false}
 			}
-			out, _ := exec.Command("docker", "system", "df", "--format", "{{.Size}}").Output()
+			out, _ := tool.Output("docker", "system", "df", "--format", "{{.Size}}")
 			lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 			if len(lines) > 0 && lines[0] != "" {
 				return ResourceResult{Value: strings.Join(lines, " + "), Style: "muted", Available: true}
@@ -314,7 +312,7 @@ var ProcessChecks = []ProcessCheck{
 		Name: "top cpu",
 		CheckFn: func() []ProcessInfo {
 			// ps -arcwwwxo pid,%cpu,comm (sorted by CPU descending)
-			out, err := exec.Command("ps", "-arcwwwxo", "pid,%cpu,comm").Output()
+			out, err := tool.Output("ps", "-arcwwwxo", "pid,%cpu,comm")
 			if err != nil {
 				return nil
 			}
@@ -325,7 +323,7 @@ var ProcessChecks = []ProcessCheck{
 		Name: "top memory",
 		CheckFn: func() []ProcessInfo {
 			// ps -amcwwwxo pid,rss,comm (sorted by memory descending, RSS in KB)
-			out, err := exec.Command("ps", "-amcwwwxo", "pid,rss,comm").Output()
+			out, err := tool.Output("ps", "-amcwwwxo", "pid,rss,comm")
 			if err != nil {
 				return nil
 			}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// Run log retention: older runs are pruned when a new run starts
+const (
+	runLogKeep   = 50
+	runLogMaxAge = 30 * 24 * time.Hour
+)
+
+// Run statuses recorded in the index
+const (
+	RunRunning = "running"
+	RunOK      = "ok"
+	RunFailed  = "failed"
+)
+
+// RunLog is one run of a mutating command in the logs index
+type RunLog struct {
+	ID       string        `json:"id"`
+	Command  string        `json:"command"` // e.g. "j install go"
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Status   string        `json:"status"`
+	Commands int           `json:"commands"` // Logged subprocesses run
+	Failed   int           `json:"failed"`   // Logged subprocesses that failed
+}
+
+// LogsDir returns the directory holding run logs and their index
+func LogsDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".local", "state", "jterrazz", "logs")
+}
+
+// RunLogPath returns the log file of a run
+func RunLogPath(id string) string {
+	return filepath.Join(LogsDir(), id+".log")
+}
+
+func runLogIndexPath() string {
+	return filepath.Join(LogsDir(), "index.json")
+}
+
+// ReadRunLogs returns the logged runs, oldest first
+func ReadRunLogs() ([]RunLog, error) {
+	data, err := os.ReadFile(runLogIndexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", runLogIndexPath(), err)
+	}
+	var runs []RunLog
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", runLogIndexPath(), err)
+	}
+	return runs, nil
+}
+
+// FindRunLog returns the run with the given id ("last" for the most recent)
+func FindRunLog(id string) (RunLog, error) {
+	runs, err := ReadRunLogs()
+	if err != nil {
+		return RunLog{}, err
+	}
+	if id == "last" && len(runs) > 0 {
+		return runs[len(runs)-1], nil
+	}
+	for _, run := range runs {
+		if run.ID == id {
+			return run, nil
+		}
+	}
+	return RunLog{}, fmt.Errorf("%w. Run: j logs", UnknownError("run", id))
+}
+
+func writeRunLogs(runs []RunLog) error {
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := runLogIndexPath() + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, runLogIndexPath())
+}
+
+// updateRunLog replaces the index entry of run
+func updateRunLog(run RunLog) error {
+	runs, err := ReadRunLogs()
+	if err != nil {
+		return err
+	}
+	for i := range runs {
+		if runs[i].ID == run.ID {
+			runs[i] = run
+			return writeRunLogs(runs)
+		}
+	}
+	return writeRunLogs(append(runs, run))
+}
+
+// pruneRunLogs keeps the runLogKeep most recent runs younger than runLogMaxAge
+// and returns the runs whose logs should be deleted
+func pruneRunLogs(runs []RunLog, now time.Time) (kept, pruned []RunLog) {
+	for i, run := range runs {
+		if len(runs)-i > runLogKeep || now.Sub(run.Started) > runLogMaxAge {
+			pruned = append(pruned, run)
+			continue
+		}
+		kept = append(kept, run)
+	}
+	return kept, pruned
+}
+
+// ActiveRunLog tees the installs, upgrades and removals of the current command
+// (commands run with tool.Cmd.Logged) into its log file
+type ActiveRunLog struct {
+	RunLog
+	file    *os.File
+	runner  *tool.LoggingRunner
+	restore func()
+}
+
+// StartRunLog prunes old runs, creates the log file of a new run and routes
+// logged subprocesses through it until Finish
+func StartRunLog(command string) (*ActiveRunLog, error) {
+	if err := os.MkdirAll(LogsDir(), 0755); err != nil {
+		return nil, err
+	}
+	runs, err := ReadRunLogs()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	runs, pruned := pruneRunLogs(runs, now)
+	for _, run := range pruned {
+		os.Remove(RunLogPath(run.ID))
+	}
+
+	run := RunLog{ID: newRunID(runs, now), Command: command, Started: now, Status: RunRunning}
+	file, err := os.Create(RunLogPath(run.ID))
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(file, "# %s\n# started %s\n\n", command, now.Format(time.RFC3339))
+
+	if err := writeRunLogs(append(runs, run)); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	active := &ActiveRunLog{RunLog: run, file: file}
+	active.runner = tool.NewLoggingRunner(tool.CurrentRunner(), file)
+	active.restore = tool.SetRunner(active.runner)
+	return active, nil
+}
+
+// Finish restores the runner and records the outcome of the run. A run fails
+// when the command returned an error or any logged subprocess failed; failing
+// checks and probes do not count.
+func (a *ActiveRunLog) Finish(cmdErr error) error {
+	a.restore()
+	a.Duration = time.Since(a.Started).Round(time.Millisecond)
+	a.Commands, a.Failed = a.runner.Counts()
+	a.Status = RunOK
+	if cmdErr != nil || a.Failed > 0 {
+		a.Status = RunFailed
+	}
+	if cmdErr != nil {
+		fmt.Fprintf(a.file, "# error: %v\n", cmdErr)
+	}
+	fmt.Fprintf(a.file, "# %s in %s\n", a.Status, a.Duration)
+	if err := a.file.Close(); err != nil {
+		return err
+	}
+	return updateRunLog(a.RunLog)
+}
+
+// newRunID returns a timestamp id, suffixed when several runs start in the same second
+func newRunID(runs []RunLog, now time.Time) string {
+	base := now.Format("20060102-150405")
+	taken := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		taken[run.ID] = true
+	}
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestRunLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fake := tool.NewFakeRunner().
+		On("brew install nope", tool.FakeResponse{Stderr: "No available formula", Err: errors.New("exit status 1")}).
+		On("npm outdated -g", tool.FakeResponse{Err: errors.New("exit status 1")})
+	t.Cleanup(tool.SetRunner(fake))
+
+	run, err := StartRunLog("j install go nope")
+	if err != nil {
+		t.Fatalf("StartRunLog() error = %v", err)
+	}
+	tool.RunLogged("brew", "install", "go")
+	tool.RunCmd(tool.Cmd{Name: "brew", Args: []string{"install", "nope"}, Stderr: &strings.Builder{}, Logged: true})
+	tool.RunSilent("npm", "outdated", "-g") // A check: neither logged nor counted
+	if err := run.Finish(nil); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if tool.CurrentRunner() != tool.Runner(fake) {
+		t.Error("Finish() should restore the previous runner")
+	}
+
+	got, err := FindRunLog("last")
+	if err != nil {
+		t.Fatalf("FindRunLog() error = %v", err)
+	}
+	if got.ID != run.ID || got.Command != "j install go nope" || got.Status != RunFailed || got.Commands != 2 || got.Failed != 1 {
+		t.Errorf("FindRunLog() = %+v", got)
+	}
+
+	data, err := os.ReadFile(RunLogPath(run.ID))
+	if err != nil {
+		t.Fatalf("log file: %v", err)
+	}
+	for _, want := range []string{"$ brew install go\n[ok in", "No available formula", "# failed in"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("log = %q, want containing %q", data, want)
+		}
+	}
+	if strings.Contains(string(data), "npm outdated") {
+		t.Errorf("log = %q, checks should not be logged", data)
+	}
+}
+
+func TestPruneRunLogs(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	var runs []RunLog
+	runs = append(runs, RunLog{ID: "old", Started: now.Add(-runLogMaxAge - time.Hour)})
+	for i := range runLogKeep + 2 {
+		runs = append(runs, RunLog{ID: fmt.Sprint(i), Started: now.Add(-time.Duration(runLogKeep+2-i) * time.Minute)})
+	}
+
+	kept, pruned := pruneRunLogs(runs, now)
+	if len(kept) != runLogKeep {
+		t.Errorf("kept %d runs, want %d", len(kept), runLogKeep)
+	}
+	var prunedIDs []string
+	for _, run := range pruned {
+		prunedIDs = append(prunedIDs, run.ID)
+	}
+	if strings.Join(prunedIDs, ",") != "old,0,1" {
+		t.Errorf("pruned = %v, want [old 0 1]", prunedIDs)
+	}
+	if kept[len(kept)-1].ID != fmt.Sprint(runLogKeep+1) {
+		t.Errorf("the most recent run should be kept, got %v", kept[len(kept)-1].ID)
+	}
+}
+
+func TestNewRunID(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 30, 12, 0, time.UTC)
+	runs := []RunLog{{ID: "20250314-093012"}, {ID: "20250314-093012-2"}}
+	if got := newRunID(runs, now); got != "20250314-093012-3" {
+		t.Errorf("newRunID() = %q, want 20250314-093012-3", got)
+	}
+}
@@ -3,7 +3,6 @@ package config
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -38,6 +37,9 @@ type Script struct {
 	// Run - execute the script
 	RunFn func() error
 
+	// Revert - undo the script when its tool is uninstalled (optional)
+	RevertFn func() error
+
 	// ExecArgs - when set, the script runs via tea.ExecProcess (suspends TUI)
 	// Use for interactive commands that need full terminal control
 	ExecArgs []string
@@ -76,7 +78,8 @@ var Scripts = []Script{
 			}
 			return CheckResult{}
 		},
-		RunFn: runGhosttyConfig,
+		RunFn:    runGhosttyConfig,
+		RevertFn: func() error { return removeConfigFile(os.Getenv("HOME") + "/.config/ghostty/config") },
 	},
 	{
 		Name:         "tmux",
@@ -90,7 +93,8 @@ var Scripts = []Script{
 			}
 			return CheckResult{}
 		},
-		RunFn: runTmuxConfig,
+		RunFn:    runTmuxConfig,
+		RevertFn: func() error { return removeConfigFile(os.Getenv("HOME") + "/.tmux.conf") },
 	},
 
 	// ==========================================================================
@@ -102,7 +106,7 @@ var Scripts = []Script{
 		Category:     ScriptCategorySecurity,
 		RequiresTool: "gpg",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("git", "config", "--global", "commit.gpgsign").Output()
+			out, _ := tool.Output("git", "config", "--global", "commit.gpgsign")
 			if strings.TrimSpace(string(out)) == "true" {
 				return CheckResult{Installed: true, Detail: "commit.gpgsign=true"}
 			}
@@ -129,7 +133,7 @@ var Scripts = []Script{
 		Category:     ScriptCategorySecurity,
 		RequiresTool: "gh",
 		CheckFn: func() CheckResult {
-			if err := exec.Command("gh", "auth", "status").Run(); err != nil {
+			if err := tool.RunSilent("gh", "auth", "status"); err != nil {
 				return CheckResult{}
 			}
 			return InstalledWithDetail("authenticated")
@@ -176,7 +180,8 @@ var Scripts = []Script{
 			}
 			return CheckResult{}
 		},
-		RunFn: runZedConfig,
+		RunFn:    runZedConfig,
+		RevertFn: func() error { return removeConfigFile(os.Getenv("HOME") + "/.config/zed/settings.json") },
 	},
 
 	// ==========================================================================
@@ -193,7 +198,8 @@ var Scripts = []Script{
 			}
 			return CheckResult{}
 		},
-		RunFn: runJavaSymlink,
+		RunFn:    runJavaSymlink,
+		RevertFn: revertJavaSymlink,
 	},
 	{
 		Name:        "dock-reset",
@@ -280,7 +286,7 @@ func runTmuxConfig() error {
 	}
 
 	// Reload tmux config if a server is running.
-	if err := exec.Command("tmux", "source-file", configPath).Run(); err == nil {
+	if err := tool.RunSilent("tmux", "source-file", configPath); err == nil {
 		fmt.Println(out.Green("Done - tmux config installed and reloaded"))
 		return nil
 	}
@@ -299,8 +305,7 @@ func runGPGSetup() error {
 		return fmt.Errorf("GPG not installed. Run: brew install gnupg")
 	}
 
-	checkCmd := exec.Command("gpg", "--list-secret-keys", "--keyid-format", "long", email)
-	if output, err := checkCmd.Output(); err == nil && len(output) > 0 {
+	if output, err := tool.Output("gpg", "--list-secret-keys", "--keyid-format", "long", email); err == nil && len(output) > 0 {
 		fmt.Println(out.Green("GPG key already exists for " + email))
 		configureGitGPG(email)
 		return nil
@@ -318,11 +323,15 @@ Expire-Date: 0
 %%commit
 `, name, email)
 
-	genCmd := exec.Command("gpg", "--batch", "--generate-key")
-	genCmd.Stdin = strings.NewReader(batchConfig)
-	genCmd.Stdout = os.Stdout
-	genCmd.Stderr = os.Stderr
-	if err := genCmd.Run(); err != nil {
+	genCmd := tool.Cmd{
+		Name:   "gpg",
+		Args:   []string{"--batch", "--generate-key"},
+		Stdin:  strings.NewReader(batchConfig),
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		Logged: true,
+	}
+	if err := tool.RunCmd(genCmd); err != nil {
 		return fmt.Errorf("failed to generate GPG key: %w", err)
 	}
 	fmt.Println(out.Green("GPG key generated"))
@@ -332,8 +341,7 @@ Expire-Date: 0
 }
 
 func configureGitGPG(email string) {
-	listCmd := exec.Command("gpg", "--list-secret-keys", "--keyid-format", "long", email)
-	output, err := listCmd.Output()
+	output, err := tool.Output("gpg", "--list-secret-keys", "--keyid-format", "long", email)
 	if err != nil {
 		out.Error("Failed to list GPG keys")
 		return
@@ -358,18 +366,16 @@ func configureGitGPG(email string) {
 
 	fmt.Println("Configuring Git to use GPG key...")
 
-	exec.Command("git", "config", "--global", "user.signingkey", keyID).Run()
-	exec.Command("git", "config", "--global", "commit.gpgsign", "true").Run()
-	exec.Command("git", "config", "--global", "gpg.program", "gpg").Run()
+	tool.RunLogged("git", "config", "--global", "user.signingkey", keyID)
+	tool.RunLogged("git", "config", "--global", "commit.gpgsign", "true")
+	tool.RunLogged("git", "config", "--global", "gpg.program", "gpg")
 
 	fmt.Println(out.Green("Git configured for commit signing"))
 
 	fmt.Println()
 	fmt.Println("Your GPG public key (add to GitHub):")
 	fmt.Println("----------------------------------------")
-	exportCmd := exec.Command("gpg", "--armor", "--export", email)
-	exportCmd.Stdout = os.Stdout
-	exportCmd.Run()
+	tool.RunCmd(tool.Cmd{Name: "gpg", Args: []string{"--armor", "--export", email}, Stdout: os.Stdout})
 	fmt.Println("----------------------------------------")
 	fmt.Println("Add at: https://github.com/settings/gpg/new")
 
@@ -396,11 +402,7 @@ func runSSHSetup() error {
 		fmt.Println(out.Dimmed("You'll be prompted to create a passphrase"))
 		fmt.Println()
 
-		genCmd := exec.Command("ssh-keygen", "-t", "ed25519", "-C", email, "-f", sshKey)
-		genCmd.Stdin = os.Stdin
-		genCmd.Stdout = os.Stdout
-		genCmd.Stderr = os.Stderr
-		if err := genCmd.Run(); err != nil {
+		if err := tool.RunLoggedInteractive("ssh-keygen", "-t", "ed25519", "-C", email, "-f", sshKey); err != nil {
 			return fmt.Errorf("failed to generate SSH key: %w", err)
 		}
 		fmt.Println(out.Green("SSH key generated"))
@@ -431,11 +433,7 @@ Host *
 	fmt.Println(out.Dimmed("Passphrase will be stored in macOS Keychain"))
 	fmt.Println()
 
-	addCmd := exec.Command("ssh-add", "--apple-use-keychain", sshKey)
-	addCmd.Stdin = os.Stdin
-	addCmd.Stdout = os.Stdout
-	addCmd.Stderr = os.Stderr
-	if err := addCmd.Run(); err != nil {
+	if err := tool.RunLoggedInteractive("ssh-add", "--apple-use-keychain", sshKey); err != nil {
 		return fmt.Errorf("failed to add key to SSH agent: %w", err)
 	}
 
@@ -522,11 +520,7 @@ func runJavaSymlink() error {
 
 	fmt.Println("Creating symlink for macOS Java recognition...")
 
-	sudoCmd := exec.Command("sudo", "ln", "-sfn", brewJava, symlinkPath)
-	sudoCmd.Stdout = os.Stdout
-	sudoCmd.Stderr = os.Stderr
-	sudoCmd.Stdin = os.Stdin
-	if err := sudoCmd.Run(); err != nil {
+	if err := tool.RunLogged("sudo", "ln", "-sfn", brewJava, symlinkPath); err != nil {
 		return fmt.Errorf("failed to create symlink: %w", err)
 	}
 
@@ -534,24 +528,47 @@ func runJavaSymlink() error {
 	return nil
 }
 
+func revertJavaSymlink() error {
+	symlinkPath := "/Library/Java/JavaVirtualMachines/openjdk.jdk"
+	if _, err := os.Lstat(symlinkPath); err != nil {
+		return nil
+	}
+
+	fmt.Println("Removing Java symlink...")
+	if err := tool.RunLogged("sudo", "rm", symlinkPath); err != nil {
+		return fmt.Errorf("failed to remove symlink: %w", err)
+	}
+
+	fmt.Println(out.Green("Done - Java symlink removed"))
+	return nil
+}
+
+// removeConfigFile deletes a config file installed by a script
+func removeConfigFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
 func runDockReset() error {
 	fmt.Println(out.Cyan("Resetting macOS Dock..."))
-	ExecCommand("defaults", "delete", "com.apple.dock")
-	ExecCommand("killall", "Dock")
+	tool.RunLogged("defaults", "delete", "com.apple.dock")
+	tool.RunLogged("killall", "Dock")
 	fmt.Println(out.Green("Done - Dock reset to defaults"))
 	return nil
 }
 
 func runDockSpacer() error {
 	fmt.Println(out.Cyan("Adding spacer to Dock..."))
-	ExecCommand("defaults", "write", "com.apple.dock", "persistent-apps", "-array-add", `{"tile-type"="small-spacer-tile";}`)
-	ExecCommand("killall", "Dock")
+	tool.RunLogged("defaults", "write", "com.apple.dock", "persistent-apps", "-array-add", `{"tile-type"="small-spacer-tile";}`)
+	tool.RunLogged("killall", "Dock")
 	fmt.Println(out.Green("Done - Dock spacer added"))
 	return nil
 }
 
 func IsDNSProfileInstalled() bool {
-	out, _ := exec.Command("profiles", "-C", "-v").Output()
+	out, _ := tool.Output("profiles", "-C", "-v")
 	return strings.Contains(string(out), dnsProfileIdentifier)
 }
 
@@ -561,7 +578,7 @@ func dnsProfilePath() string {
 
 func runDNSEncrypt() error {
 	if IsDNSProfileInstalled() {
-		exec.Command("open", "x-apple.systempreferences:com.apple.Profiles-Settings.extension").Run()
+		tool.RunSilent("open", "x-apple.systempreferences:com.apple.Profiles-Settings.extension")
 		return nil
 	}
 
@@ -574,7 +591,7 @@ func runDNSEncrypt() error {
 		return fmt.Errorf("failed to write profile: %w", err)
 	}
 
-	exec.Command("open", profilePath).Run()
+	tool.RunSilent("open", profilePath)
 
 	// Give macOS time to read the file before the TUI resumes
 	time.Sleep(2 * time.Second)
@@ -1,8 +1,9 @@
 package config
 
 import (
-	"os/exec"
 	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 )
 
 // SecurityCheck represents a system security verification
@@ -19,7 +20,7 @@ var SecurityChecks = []SecurityCheck{
 		Name:        "filevault",
 		Description: "Full disk encryption",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("fdesetup", "status").Output()
+			out, _ := tool.Output("fdesetup", "status")
 			return CheckResult{Installed: strings.Contains(string(out), "FileVault is On")}
 		},
 		GoodWhen: true,
@@ -28,7 +29,7 @@ var SecurityChecks = []SecurityCheck{
 		Name:        "firewall",
 		Description: "Block incoming connections",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("/usr/libexec/ApplicationFirewall/socketfilterfw", "--getglobalstate").Output()
+			out, _ := tool.Output("/usr/libexec/ApplicationFirewall/socketfilterfw", "--getglobalstate")
 			return CheckResult{Installed: strings.Contains(string(out), "enabled")}
 		},
 		GoodWhen: true,
@@ -37,7 +38,7 @@ var SecurityChecks = []SecurityCheck{
 		Name:        "sip",
 		Description: "System Integrity Protection",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("csrutil", "status").Output()
+			out, _ := tool.Output("csrutil", "status")
 			return CheckResult{Installed: strings.Contains(string(out), "enabled")}
 		},
 		GoodWhen: true,
@@ -46,7 +47,7 @@ var SecurityChecks = []SecurityCheck{
 		Name:        "gatekeeper",
 		Description: "App signature verification",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("spctl", "--status").Output()
+			out, _ := tool.Output("spctl", "--status")
 			return CheckResult{Installed: strings.Contains(string(out), "enabled")}
 		},
 		GoodWhen: true,
@@ -55,7 +56,7 @@ var SecurityChecks = []SecurityCheck{
 		Name:        "remote-login",
 		Description: "SSH server disabled",
 		CheckFn: func() CheckResult {
-			out, _ := exec.Command("launchctl", "list").Output()
+			out, _ := tool.Output("launchctl", "list")
 			sshRunning := strings.Contains(string(out), "com.openssh.sshd")
 			return CheckResult{Installed: !sshRunning}
 		},
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// Language package managers install CLIs into their own bin directory:
+// cargo install, uv tool install, pipx install and go install.
+
+// CargoBinDir is where `cargo install` puts binaries ($CARGO_HOME/bin)
+func CargoBinDir() string {
+	if home := os.Getenv("CARGO_HOME"); home != "" {
+		return filepath.Join(home, "bin")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".cargo", "bin")
+}
+
+// UvBinDir is where `uv tool install` puts binaries ($UV_TOOL_BIN_DIR)
+func UvBinDir() string {
+	if dir := os.Getenv("UV_TOOL_BIN_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "bin")
+}
+
+// PipxBinDir is where `pipx install` puts binaries ($PIPX_BIN_DIR)
+func PipxBinDir() string {
+	if dir := os.Getenv("PIPX_BIN_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "bin")
+}
+
+// GoBinDir is where `go install` puts binaries ($GOBIN, else $GOPATH/bin)
+func GoBinDir() string {
+	if dir := os.Getenv("GOBIN"); dir != "" {
+		return dir
+	}
+	if gopath := filepath.SplitList(os.Getenv("GOPATH")); len(gopath) > 0 && gopath[0] != "" {
+		return filepath.Join(gopath[0], "bin")
+	}
+	return filepath.Join(os.Getenv("HOME"), "go", "bin")
+}
+
+// isToolchainMethod reports whether the method installs with a language package manager
+func isToolchainMethod(m InstallMethod) bool {
+	switch m {
+	case InstallCargo, InstallUv, InstallPipx, InstallGo:
+		return true
+	}
+	return false
+}
+
+// goPackage returns the package path of a go tool, without its version
+// ("golang.org/x/tools/gopls@v0.16.2" -> "golang.org/x/tools/gopls")
+func (t Tool) goPackage() string {
+	pkg, _, _ := strings.Cut(t.Formula, "@")
+	return pkg
+}
+
+// goInstallTarget returns the argument of `go install`, @latest unless Formula pins a version
+func (t Tool) goInstallTarget() string {
+	if strings.Contains(t.Formula, "@") {
+		return t.Formula
+	}
+	return t.Formula + "@latest"
+}
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// goBinary returns the path `go install` writes the tool binary to. Like go,
+// the name is the last path element, skipping a major version suffix (/v2).
+func (t Tool) goBinary() string {
+	name := t.Command
+	if name == "" {
+		pkg := t.goPackage()
+		name = path.Base(pkg)
+		if majorVersionSuffix.MatchString(name) && strings.Contains(pkg, "/") {
+			name = path.Base(path.Dir(pkg))
+		}
+	}
+	return filepath.Join(GoBinDir(), name)
+}
+
+// uninstallGoBinary removes the binary of a go tool: go has no uninstall
+func (t Tool) uninstallGoBinary() error {
+	if err := os.Remove(t.goBinary()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// checkToolchainPackage checks a cargo, uv, pipx or go tool in the packages
+// its manager lists
+func (t Tool) checkToolchainPackage() CheckResult {
+	version, ok := t.toolchainPackageVersion()
+	if !ok {
+		return CheckResult{}
+	}
+	return InstalledWithVersion(version)
+}
+
+// toolchainPackageVersion returns the version of the tool package as its
+// manager reports it, and whether the package is installed
+func (t Tool) toolchainPackageVersion() (string, bool) {
+	var (
+		out      []byte
+		err      error
+		packages func(string) map[string]string
+	)
+	switch t.Method {
+	case InstallCargo:
+		out, err = tool.Output("cargo", "install", "--list")
+		packages = tool.ParseCargoInstallList
+	case InstallUv:
+		out, err = tool.Output("uv", "tool", "list")
+		packages = tool.ParseUvToolList
+	case InstallPipx:
+		out, err = tool.Output("pipx", "list", "--short")
+		packages = tool.ParsePipxList
+	case InstallGo:
+		binary := t.goBinary()
+		if _, err := os.Stat(binary); err != nil {
+			return "", false
+		}
+		out, _ := tool.Output("go", "version", "-m", binary)
+		return tool.ParseGoVersionM(string(out))[t.goPackage()], true
+	default:
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+	version, ok := packages(string(out))[t.Formula]
+	return version, ok
+}
+
+// cargoUpgradeCommands reinstalls every crate installed from crates.io;
+// cargo skips the ones already up to date
+func cargoUpgradeCommands() [][]string {
+	out, err := tool.Output("cargo", "install", "--list")
+	if err != nil {
+		return nil
+	}
+	crates := slices.Sorted(maps.Keys(tool.ParseCargoInstallList(string(out))))
+	if len(crates) == 0 {
+		return nil
+	}
+	return [][]string{append([]string{"cargo", "install", "--locked"}, crates...)}
+}
+
+// goUpgradeCommands reinstalls every binary in GoBinDir at its latest version
+func goUpgradeCommands() [][]string {
+	out, err := tool.Output("go", "version", "-m", GoBinDir())
+	if err != nil {
+		return nil
+	}
+	var commands [][]string
+	for _, pkg := range slices.Sorted(maps.Keys(tool.ParseGoVersionM(string(out)))) {
+		commands = append(commands, []string{"go", "install", pkg + "@latest"})
+	}
+	return commands
+}
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 )
@@ -69,6 +72,14 @@ const (
 	InstallXcode       InstallMethod = "xcode"
 	InstallManual      InstallMethod = "manual"
 	InstallMAS         InstallMethod = "mas"
+	InstallApt         InstallMethod = "apt"
+	InstallDnf         InstallMethod = "dnf"
+	InstallPacman      InstallMethod = "pacman"
+	InstallRelease     InstallMethod = "release"
+	InstallCargo       InstallMethod = "cargo"
+	InstallUv          InstallMethod = "uv"
+	InstallPipx        InstallMethod = "pipx"
+	InstallGo          InstallMethod = "go"
 )
 
 // String returns a display string for the install method
@@ -88,6 +99,8 @@ func (m InstallMethod) String() string {
 		return "sh"
 	case InstallMAS:
 		return "mas"
+	case InstallApt, InstallDnf, InstallPacman, InstallRelease, InstallCargo, InstallUv, InstallPipx, InstallGo:
+		return string(m)
 	default:
 		return "-"
 	}
@@ -96,6 +109,7 @@ func (m InstallMethod) String() string {
 // Tool represents an installable piece of software
 type Tool struct {
 	Name        string
+	Aliases     []string // Other names accepted by install, upgrade and uninstall (e.g. "java")
 	Description string
 	Category    ToolCategory
 
@@ -105,15 +119,31 @@ type Tool struct {
 
 	// Install - how to install
 	Method       InstallMethod // brew, npm, manual, etc.
-	Formula      string        // Brew formula or npm package name
+	Formula      string        // Brew formula, npm package, crate, Python package, Go package or App Store app name
+	AppStoreID   int           // Mac App Store id, for mas tools (e.g. 497799835 for Xcode)
+	App          string        // App bundle in /Applications, checked when mas cannot tell (e.g. "Xcode")
+	Release      *Release      // Release archive, for release tools
+	RemoteScript *RemoteScript // Verified installer script (overrides Method)
 	InstallFn    func() error  // Custom install (overrides Method)
+	UninstallFn  func() error  // Custom uninstall (overrides Method)
 	Dependencies []string      // Tool names this depends on
 
+	// Linux - package name per Linux package manager (apt, dnf, pacman)
+	Linux       map[InstallMethod]string
+	Unsupported bool // No install route on the current platform (set by ApplyPlatform)
+
 	// Version - how to get version info
-	VersionFn func() string // Returns version string
+	VersionFn  func() string // Returns version string
+	MinVersion string        // Oldest supported version ("1.24") or a constraint (">=1.2 <2")
+
+	// Health - whether the service behind the tool answers (daemons only)
+	HealthFn func() Health
 
 	// Scripts - post-install or related scripts
 	Scripts []string // Script names to run after install
+
+	// Source - the registry entry defining the tool (set by MergeTools)
+	Source ToolSource
 }
 
 // Tools is the single source of truth for all installable software
@@ -141,37 +171,29 @@ var Tools = []Tool{
 	},
 	{
 		Name:     "homebrew",
+		Aliases:  []string{"brew"},
 		Command:  "brew",
 		Method:   InstallManual,
 		Category: CategoryPackageManager,
 		CheckFn: func() CheckResult {
-			if _, err := exec.LookPath("brew"); err != nil {
+			if _, err := tool.LookPath("brew"); err != nil {
 				return CheckResult{}
 			}
-			out, _ := exec.Command("brew", "--version").Output()
+			out, _ := tool.Output("brew", "--version")
 			version := tool.ParseBrewVersion(string(out))
-			formulaeOut, _ := exec.Command("brew", "list", "--formula", "-1").Output()
-			caskOut, _ := exec.Command("brew", "list", "--cask", "-1").Output()
-			formulaeCount := 0
-			caskCount := 0
-			if len(strings.TrimSpace(string(formulaeOut))) > 0 {
-				formulaeCount = len(strings.Split(strings.TrimSpace(string(formulaeOut)), "\n"))
-			}
-			if len(strings.TrimSpace(string(caskOut))) > 0 {
-				caskCount = len(strings.Split(strings.TrimSpace(string(caskOut)), "\n"))
+			status := ""
+			if inv, err := tool.Brew(); err == nil {
+				status = fmt.Sprintf("%d formulae, %d casks", len(inv.Formulae), len(inv.Casks))
 			}
 			return CheckResult{
 				Installed: true,
 				Version:   version,
-				Status:    fmt.Sprintf("%d formulae, %d casks", formulaeCount, caskCount),
+				Status:    status,
 			}
 		},
-		InstallFn: func() error {
-			cmd := exec.Command("/bin/bash", "-c", "$(curl -fsSL https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh)")
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			cmd.Stdin = os.Stdin
-			return cmd.Run()
+		RemoteScript: &RemoteScript{
+			URL:   "https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh",
+			Shell: []string{"/bin/bash"},
 		},
 	},
 	{
@@ -181,12 +203,12 @@ var Tools = []Tool{
 		Category:     CategoryPackageManager,
 		Dependencies: []string{"node"},
 		CheckFn: func() CheckResult {
-			if _, err := exec.LookPath("npm"); err != nil {
+			if _, err := tool.LookPath("npm"); err != nil {
 				return CheckResult{}
 			}
-			out, _ := exec.Command("npm", "--version").Output()
+			out, _ := tool.Output("npm", "--version")
 			version := tool.TrimVersion(string(out))
-			npmOut, _ := exec.Command("npm", "list", "-g", "--depth=0", "--parseable").Output()
+			npmOut, _ := tool.Output("npm", "list", "-g", "--depth=0", "--parseable")
 			npmLines := strings.Split(strings.TrimSpace(string(npmOut)), "\n")
 			count := len(npmLines) - 1
 			if count < 0 {
@@ -199,6 +221,16 @@ var Tools = []Tool{
 			}
 		},
 	},
+	{
+		Name:         "mas",
+		Description:  "Mac App Store CLI",
+		Command:      "mas",
+		Formula:      "mas",
+		Method:       InstallBrewFormula,
+		Category:     CategoryPackageManager,
+		Dependencies: []string{"homebrew"},
+		VersionFn:    tool.VersionFromCmd("mas", []string{"version"}, tool.TrimVersion),
+	},
 	{
 		Name:         "nvm",
 		Command:      "",
@@ -229,6 +261,16 @@ var Tools = []Tool{
 			return CheckResult{Installed: true, Version: version, Status: status}
 		},
 	},
+	{
+		Name:         "pipx",
+		Command:      "pipx",
+		Formula:      "pipx",
+		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "pipx", InstallDnf: "pipx", InstallPacman: "python-pipx"},
+		Category:     CategoryPackageManager,
+		Dependencies: []string{"homebrew"},
+		VersionFn:    tool.VersionFromCmd("pipx", []string{"--version"}, tool.TrimVersion),
+	},
 	{
 		Name:         "pnpm",
 		Command:      "pnpm",
@@ -239,6 +281,17 @@ var Tools = []Tool{
 		VersionFn:    tool.VersionFromCmd("pnpm", []string{"--version"}, tool.TrimVersion),
 	},
 
+	{
+		Name:         "uv",
+		Command:      "uv",
+		Formula:      "uv",
+		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallPacman: "uv"},
+		Category:     CategoryPackageManager,
+		Dependencies: []string{"homebrew"},
+		VersionFn:    tool.VersionFromCmd("uv", []string{"--version"}, tool.ParseUvVersion),
+	},
+
 	// ==========================================================================
 	// Runtimes
 	// ==========================================================================
@@ -247,9 +300,11 @@ var Tools = []Tool{
 		Command:      "go",
 		Formula:      "go",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "golang-go", InstallDnf: "golang", InstallPacman: "go"},
 		Category:     CategoryRuntimes,
 		Dependencies: []string{"homebrew"},
 		VersionFn:    tool.VersionFromCmd("go", []string{"version"}, tool.ParseGoVersion),
+		MinVersion:   "1.24", // Needed to build j
 	},
 	{
 		Name:         "node",
@@ -261,31 +316,34 @@ var Tools = []Tool{
 	},
 	{
 		Name:         "openjdk",
+		Aliases:      []string{"java"},
 		Command:      "java",
 		Formula:      "openjdk",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "default-jdk", InstallDnf: "java-latest-openjdk", InstallPacman: "jdk-openjdk"},
 		Category:     CategoryRuntimes,
 		Dependencies: []string{"homebrew"},
 		Scripts:      []string{"java"},
 		CheckFn: func() CheckResult {
 			brewJava := "/opt/homebrew/opt/openjdk/bin/java"
 			if _, err := os.Stat(brewJava); err == nil {
-				out, _ := exec.Command(brewJava, "-version").CombinedOutput()
+				out, _ := tool.CombinedOutput(brewJava, "-version")
 				return CheckResult{Installed: true, Version: tool.ParseJavaVersion(string(out))}
 			}
-			cmd := exec.Command("/usr/libexec/java_home")
-			if err := cmd.Run(); err != nil {
+			if err := tool.RunSilent("/usr/libexec/java_home"); err != nil {
 				return CheckResult{}
 			}
-			out, _ := exec.Command("java", "-version").CombinedOutput()
+			out, _ := tool.CombinedOutput("java", "-version")
 			return CheckResult{Installed: true, Version: tool.ParseJavaVersion(string(out))}
 		},
 	},
 	{
 		Name:         "python",
+		Aliases:      []string{"python3"},
 		Command:      "python3",
 		Formula:      "python",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "python3", InstallDnf: "python3", InstallPacman: "python"},
 		Category:     CategoryRuntimes,
 		Dependencies: []string{"homebrew"},
 		VersionFn:    tool.VersionFromCmd("python3", []string{"--version"}, tool.ParsePythonVersion),
@@ -295,6 +353,7 @@ var Tools = []Tool{
 		Command:      "rustc",
 		Formula:      "rust",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "rustc", InstallDnf: "rust", InstallPacman: "rust"},
 		Category:     CategoryRuntimes,
 		Dependencies: []string{"homebrew"},
 		VersionFn:    tool.VersionFromCmd("rustc", []string{"--version"}, tool.ParseRustVersion),
@@ -308,6 +367,7 @@ var Tools = []Tool{
 		Command:      "ansible",
 		Formula:      "ansible",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "ansible", InstallDnf: "ansible", InstallPacman: "ansible"},
 		Category:     CategoryDevOps,
 		Dependencies: []string{"homebrew"},
 		VersionFn:    tool.VersionFromCmd("ansible", []string{"--version"}, tool.ParseAnsibleVersion),
@@ -359,12 +419,9 @@ var Tools = []Tool{
 		Method:    InstallManual,
 		Category:  CategoryAI,
 		VersionFn: tool.VersionFromCmd("claude", []string{"--version"}, tool.ParseClaudeVersion),
-		InstallFn: func() error {
-			cmd := exec.Command("bash", "-c", "curl -fsSL https://claude.ai/install.sh | bash")
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			cmd.Stdin = os.Stdin
-			return cmd.Run()
+		RemoteScript: &RemoteScript{
+			URL:   "https://claude.ai/install.sh",
+			Shell: []string{"bash"},
 		},
 	},
 	{
@@ -398,12 +455,9 @@ var Tools = []Tool{
 				return CheckResult{}
 			}
 			version := tool.VersionFromBrewCask("ollama-app")()
-			status := "stopped"
-			if err := exec.Command("pgrep", "-x", "ollama").Run(); err == nil {
-				status = "running"
-			}
-			return CheckResult{Installed: true, Version: version, Status: status}
+			return CheckResult{Installed: true, Version: version}
 		},
+		HealthFn: ollamaHealth,
 	},
 	{
 		Name:         "opencode",
@@ -446,6 +500,7 @@ var Tools = []Tool{
 	// ==========================================================================
 	{
 		Name:         "orbstack",
+		Aliases:      []string{"docker"},
 		Description:  "OrbStack container runtime (provides docker CLI)",
 		Formula:      "orbstack",
 		Method:       InstallBrewCask,
@@ -456,12 +511,9 @@ var Tools = []Tool{
 				return CheckResult{}
 			}
 			version := tool.VersionFromAppPlist("OrbStack")()
-			status := "stopped"
-			if err := exec.Command("docker", "info").Run(); err == nil {
-				status = "running"
-			}
-			return CheckResult{Installed: true, Version: version, Status: status}
+			return CheckResult{Installed: true, Version: version}
 		},
+		HealthFn: dockerHealth,
 	},
 	{
 		Name:         "conductor",
@@ -498,6 +550,7 @@ var Tools = []Tool{
 		Command:      "gpg",
 		Formula:      "gnupg",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "gnupg", InstallDnf: "gnupg2", InstallPacman: "gnupg"},
 		Category:     CategoryTerminalGit,
 		Dependencies: []string{"homebrew"},
 		Scripts:      []string{"gpg"},
@@ -515,20 +568,16 @@ var Tools = []Tool{
 				return CheckResult{}
 			}
 			// Get git commit hash as version
-			cmd := exec.Command("git", "-C", omzPath, "rev-parse", "--short", "HEAD")
-			out, err := cmd.Output()
+			out, err := tool.Output("git", "-C", omzPath, "rev-parse", "--short", "HEAD")
 			version := ""
 			if err == nil {
 				version = strings.TrimSpace(string(out))
 			}
 			return CheckResult{Installed: true, Version: version}
 		},
-		InstallFn: func() error {
-			cmd := exec.Command("sh", "-c", "$(curl -fsSL https://raw.githubusercontent.com/ohmyzsh/ohmyzsh/master/tools/install.sh)")
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			cmd.Stdin = os.Stdin
-			return cmd.Run()
+		RemoteScript: &RemoteScript{
+			URL:   "https://raw.githubusercontent.com/ohmyzsh/ohmyzsh/master/tools/install.sh",
+			Shell: []string{"sh"},
 		},
 	},
 	{
@@ -736,15 +785,9 @@ var Tools = []Tool{
 		Category:     CategoryGUIApps,
 		Dependencies: []string{"homebrew"},
 		CheckFn: func() CheckResult {
-			if _, err := exec.LookPath("tailscale"); err == nil {
+			if _, err := tool.LookPath("tailscale"); err == nil {
 				version := tool.VersionFromCmd("tailscale", []string{"version"}, tool.ParseTailscaleVersion)()
-				status := "installed"
-				if out, err := exec.Command("tailscale", "status", "--json").Output(); err == nil {
-					if strings.Contains(string(out), `"BackendState":"Running"`) {
-						status = "running"
-					}
-				}
-				return CheckResult{Installed: true, Version: version, Status: status}
+				return CheckResult{Installed: true, Version: version}
 			}
 			if _, err := os.Stat("/Applications/Tailscale.app"); err == nil {
 				version := tool.VersionFromAppPlist("Tailscale")()
@@ -753,6 +796,7 @@ var Tools = []Tool{
 			}
 			return CheckResult{}
 		},
+		HealthFn: tailscaleHealth,
 	},
 	{
 		Name:         "whatsapp",
@@ -777,6 +821,7 @@ var Tools = []Tool{
 		Name:      "git",
 		Command:   "git",
 		Method:    InstallXcode,
+		Linux:     map[InstallMethod]string{InstallApt: "git", InstallDnf: "git", InstallPacman: "git"},
 		Category:  CategoryTerminalGit,
 		VersionFn: tool.VersionFromCmd("git", []string{"--version"}, tool.ParseGitVersion),
 	},
@@ -785,6 +830,7 @@ var Tools = []Tool{
 		Command:      "tmux",
 		Formula:      "tmux",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "tmux", InstallDnf: "tmux", InstallPacman: "tmux"},
 		Category:     CategoryTerminalGit,
 		Dependencies: []string{"homebrew"},
 		Scripts:      []string{"tmux"},
@@ -796,6 +842,7 @@ var Tools = []Tool{
 		Command:      "gh",
 		Formula:      "gh",
 		Method:       InstallBrewFormula,
+		Linux:        map[InstallMethod]string{InstallApt: "gh", InstallDnf: "gh", InstallPacman: "github-cli"},
 		Category:     CategoryTerminalGit,
 		Dependencies: []string{"homebrew"},
 		Scripts:      []string{"gh"},
@@ -822,201 +869,135 @@ var Tools = []Tool{
 	},
 
 	// ==========================================================================
-	// Mac App Store (check-only, not auto-installable)
+	// Mac App Store (installed with mas when they have an App Store id)
 	// ==========================================================================
 	{
-		Name:        "adguard",
-		Description: "Ad blocker for Safari",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/AdGuard for Safari.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("AdGuard for Safari")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "adguard",
+		Description:  "Ad blocker for Safari",
+		AppStoreID:   1440147259,
+		App:          "AdGuard for Safari",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
 		Name:     "broadcasts",
+		App:      "Broadcasts",
 		Method:   InstallMAS,
 		Category: CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Broadcasts.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Broadcasts")()
-			return CheckResult{Installed: true, Version: version}
-		},
 	},
 	{
-		Name:        "compressor",
-		Description: "Apple video compression tool",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Compressor.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Compressor")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "compressor",
+		Description:  "Apple video compression tool",
+		AppStoreID:   424390742,
+		App:          "Compressor",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
 		Name:        "dia",
 		Description: "AI assistant by Apple",
+		App:         "Dia",
 		Method:      InstallMAS,
 		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Dia.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Dia")()
-			return CheckResult{Installed: true, Version: version}
-		},
 	},
 	{
-		Name:        "final-cut-pro",
-		Description: "Professional video editor",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Final Cut Pro.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Final Cut Pro")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "final-cut-pro",
+		Description:  "Professional video editor",
+		AppStoreID:   424389933,
+		App:          "Final Cut Pro",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "lightroom",
-		Description: "Adobe photo editor",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Adobe Lightroom.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Adobe Lightroom")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "lightroom",
+		Description:  "Adobe photo editor",
+		AppStoreID:   1451544217,
+		App:          "Adobe Lightroom",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "logic-pro",
-		Description: "Professional music production",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Logic Pro.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Logic Pro")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "logic-pro",
+		Description:  "Professional music production",
+		AppStoreID:   634148309,
+		App:          "Logic Pro",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "messenger",
-		Description: "Facebook Messenger",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Messenger.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Messenger")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "messenger",
+		Description:  "Facebook Messenger",
+		AppStoreID:   1480068668,
+		App:          "Messenger",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "pages",
-		Description: "Apple word processor",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Pages.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Pages")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "pages",
+		Description:  "Apple word processor",
+		AppStoreID:   409201541,
+		App:          "Pages",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "passepartout",
-		Description: "VPN client",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Passepartout.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Passepartout")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "passepartout",
+		Description:  "VPN client",
+		AppStoreID:   1433648537,
+		App:          "Passepartout",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "pipifier",
-		Description: "Picture-in-Picture for Safari",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/PiPifier.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("PiPifier")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "pipifier",
+		Description:  "Picture-in-Picture for Safari",
+		AppStoreID:   1160374471,
+		App:          "PiPifier",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "raindrop",
-		Description: "Bookmark manager",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Save to Raindrop.io.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Save to Raindrop.io")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "raindrop",
+		Description:  "Bookmark manager",
+		AppStoreID:   1549370672,
+		App:          "Save to Raindrop.io",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
 		Name:        "snippety",
 		Description: "Code snippet manager",
+		App:         "Snippety",
 		Method:      InstallMAS,
 		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Snippety.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Snippety")()
-			return CheckResult{Installed: true, Version: version}
-		},
 	},
 	{
-		Name:        "speedtest",
-		Description: "Internet speed test",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Speedtest.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Speedtest")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "speedtest",
+		Description:  "Internet speed test",
+		AppStoreID:   1153157709,
+		App:          "Speedtest",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 	{
-		Name:        "xcode",
-		Description: "Apple development IDE",
-		Method:      InstallMAS,
-		Category:    CategoryMacAppStore,
-		CheckFn: func() CheckResult {
-			if _, err := os.Stat("/Applications/Xcode.app"); err != nil {
-				return CheckResult{}
-			}
-			version := tool.VersionFromAppPlist("Xcode")()
-			return CheckResult{Installed: true, Version: version}
-		},
+		Name:         "xcode",
+		Description:  "Apple development IDE",
+		AppStoreID:   497799835,
+		App:          "Xcode",
+		Method:       InstallMAS,
+		Category:     CategoryMacAppStore,
+		Dependencies: []string{"mas"},
 	},
 }
 
@@ -1044,7 +1025,7 @@ func GetToolsByCategory(category ToolCategory) []Tool {
 func GetInstallableTools() []Tool {
 	var result []Tool
 	for _, tool := range Tools {
-		if tool.Method == InstallBrewFormula || tool.Method == InstallBrewCask || tool.Method == InstallNpm || tool.Method == InstallBun || tool.InstallFn != nil {
+		if tool.CanInstall() {
 			result = append(result, tool)
 		}
 	}
@@ -1061,50 +1042,159 @@ func GetToolByName(name string) *Tool {
 	return nil
 }
 
+// FindTool returns the tool with the given name or alias. Unknown names get
+// an ErrUnknownItem error suggesting the closest tools.
+func FindTool(name string) (*Tool, error) {
+	if t := GetToolByName(name); t != nil {
+		return t, nil
+	}
+	for i := range Tools {
+		if slices.Contains(Tools[i].Aliases, name) {
+			return &Tools[i], nil
+		}
+	}
+	return nil, unknownToolError(name)
+}
+
+// unknownToolError reports an unknown tool, with "did you mean" suggestions
+func unknownToolError(name string) error {
+	err := UnknownError("tool", name)
+	if suggestions := SuggestTools(name); len(suggestions) > 0 {
+		return fmt.Errorf("%w. Did you mean %s?", err, strings.Join(suggestions, ", "))
+	}
+	return err
+}
+
+// maxSuggestions caps the tools suggested for an unknown name
+const maxSuggestions = 3
+
+// SuggestTools returns the tools whose name, alias or command is closest to
+// name, closest first. Only near misses (a typo or two) are suggested.
+func SuggestTools(name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	best := make(map[string]int)
+	for _, t := range Tools {
+		if t.Unsupported {
+			continue
+		}
+		for _, key := range append([]string{t.Name, t.Command}, t.Aliases...) {
+			if key == "" {
+				continue
+			}
+			distance := tool.EditDistance(strings.ToLower(name), key)
+			if d, ok := best[t.Name]; !ok || distance < d {
+				best[t.Name] = distance
+			}
+		}
+	}
+
+	// Allow one edit per three characters, at least one
+	threshold := max(1, len([]rune(name))/3)
+	var candidates []candidate
+	for toolName, distance := range best {
+		if distance <= threshold {
+			candidates = append(candidates, candidate{toolName, distance})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	var names []string
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		names = append(names, c.name)
+	}
+	return names
+}
+
 // GetToolsInDependencyOrder returns all installable tools sorted by dependencies
 func GetToolsInDependencyOrder() []Tool {
 	installable := GetInstallableTools()
 
-	toolMap := make(map[string]*Tool)
-	for i := range installable {
-		toolMap[installable[i].Name] = &installable[i]
+	isInstallable := make(map[string]bool, len(installable))
+	names := make([]string, len(installable))
+	for i, t := range installable {
+		isInstallable[t.Name] = true
+		names[i] = t.Name
 	}
 
+	var result []Tool
+	for _, t := range GetDependencyOrder(names) {
+		if isInstallable[t.Name] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// GetDependencyOrder returns the named tools and everything they depend on,
+// each tool listed after its dependencies. Unknown names are ignored and a
+// dependency cycle is broken at the edge closing it (see ValidateRegistry).
+func GetDependencyOrder(names []string) []Tool {
 	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
 	var result []Tool
 
 	var visit func(name string)
 	visit = func(name string) {
-		if visited[name] {
+		if visited[name] || visiting[name] {
 			return
 		}
 
-		tool := toolMap[name]
-		if tool == nil {
-			tool = GetToolByName(name)
-		}
-		if tool == nil {
+		t := GetToolByName(name)
+		if t == nil {
 			return
 		}
 
-		for _, dep := range tool.Dependencies {
+		visiting[name] = true
+		for _, dep := range t.Dependencies {
 			visit(dep)
 		}
+		visiting[name] = false
 
 		visited[name] = true
-
-		if toolMap[name] != nil {
-			result = append(result, *tool)
-		}
+		result = append(result, *t)
 	}
 
-	for _, tool := range installable {
-		visit(tool.Name)
+	for _, name := range names {
+		visit(name)
 	}
 
 	return result
 }
 
+// VersionConstraint parses MinVersion. A bare version means ">=" that version.
+func (t Tool) VersionConstraint() (tool.Constraint, error) {
+	constraint := strings.TrimSpace(t.MinVersion)
+	if bare := strings.TrimPrefix(constraint, "v"); bare != "" && unicode.IsDigit(rune(bare[0])) {
+		constraint = ">=" + constraint
+	}
+	return tool.ParseConstraint(constraint)
+}
+
+// TooOld reports whether version fails MinVersion. Tools without MinVersion
+// and versions that cannot be parsed are never too old.
+func (t Tool) TooOld(version string) bool {
+	if t.MinVersion == "" || version == "" {
+		return false
+	}
+	constraint, err := t.VersionConstraint()
+	if err != nil {
+		return false
+	}
+	v, err := tool.ParseVersion(version)
+	if err != nil {
+		return false
+	}
+	return !constraint.Check(v)
+}
+
 // Check checks if a tool is installed and returns its status
 func (t Tool) Check() CheckResult {
 	if t.CheckFn != nil {
@@ -1112,47 +1202,236 @@ func (t Tool) Check() CheckResult {
 	}
 
 	if t.Command == "" {
-		return CheckResult{}
+		if t.Method == InstallMAS {
+			return t.checkAppStoreApp()
+		}
+		if isToolchainMethod(t.Method) {
+			return t.checkToolchainPackage()
+		}
+		return t.checkBrewPackage()
 	}
 
-	if _, err := exec.LookPath(t.Command); err != nil {
-		return CheckResult{}
+	if _, err := tool.LookPath(t.Command); err != nil {
+		if _, ok := t.releaseBinary(); !ok { // ReleaseBinDir may not be on PATH
+			return CheckResult{}
+		}
 	}
 
 	result := CheckResult{Installed: true}
 
 	if t.VersionFn != nil {
 		result.Version = t.VersionFn()
+	} else if isToolchainMethod(t.Method) {
+		result.Version, _ = t.toolchainPackageVersion()
 	}
 
 	return result
 }
 
+// CheckTools checks tools in parallel; results keep the order of tools
+func CheckTools(tools []Tool) []CheckResult {
+	results := make([]CheckResult, len(tools))
+	var wg sync.WaitGroup
+	for i := range tools {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = tools[i].Check()
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// checkBrewPackage checks a brew-managed tool that has no CLI command
+// (e.g. casks declared in the user manifest) via its installed version
+func (t Tool) checkBrewPackage() CheckResult {
+	var version string
+	switch t.Method {
+	case InstallBrewFormula:
+		version = tool.VersionFromBrewFormula(t.Formula)()
+	case InstallBrewCask:
+		version = tool.VersionFromBrewCask(t.Formula)()
+	}
+	if version == "" {
+		return CheckResult{}
+	}
+	return InstalledWithVersion(version)
+}
+
+// checkAppStoreApp checks a Mac App Store app by its id in `mas list`, then
+// by its bundle in /Applications when mas is missing or does not list it
+// (apps installed outside the store)
+func (t Tool) checkAppStoreApp() CheckResult {
+	if t.AppStoreID != 0 && CommandExists("mas") {
+		if out, err := tool.Output("mas", "list"); err == nil {
+			if version, ok := tool.ParseMasListVersion(string(out), t.AppStoreID); ok && version != "" {
+				return InstalledWithVersion(version)
+			}
+		}
+	}
+	return t.checkAppBundle()
+}
+
+// checkAppBundle checks the app bundle in /Applications and reads its version from Info.plist
+func (t Tool) checkAppBundle() CheckResult {
+	if t.App == "" {
+		return CheckResult{}
+	}
+	if _, err := os.Stat(filepath.Join("/Applications", t.App+".app")); err != nil {
+		return CheckResult{}
+	}
+	return CheckResult{Installed: true, Version: tool.VersionFromAppPlist(t.App)()}
+}
+
+// CanInstall reports whether j can install the tool on the current platform
+func (t Tool) CanInstall() bool {
+	if t.Unsupported {
+		return false
+	}
+	if t.InstallFn != nil || t.RemoteScript != nil {
+		return true
+	}
+	if t.Method == InstallRelease {
+		return t.Release != nil && t.Release.Asset(CurrentPlatform()) != ""
+	}
+	_, err := t.installCommand()
+	return err == nil
+}
+
 // Install installs the tool
 func (t Tool) Install() error {
+	defer tool.ResetBrewInventory() // What brew has installed may have changed
+	if t.Unsupported {
+		return fmt.Errorf("%s is not available on %s", t.Name, CurrentPlatform().OS)
+	}
 	if t.InstallFn != nil {
 		return t.InstallFn()
 	}
+	if t.RemoteScript != nil {
+		return t.runRemoteScript()
+	}
+	if t.Method == InstallRelease {
+		return t.installRelease()
+	}
 
+	args, err := t.installCommand()
+	if err != nil {
+		return err
+	}
+	return tool.RunLogged(args[0], args[1:]...)
+}
+
+// installCommand returns the command line installing the tool with its Method
+func (t Tool) installCommand() ([]string, error) {
 	switch t.Method {
 	case InstallBrewFormula:
-		return RunBrewCommand("install", t.Formula)
+		return brewCommand("install", t.Formula), nil
 	case InstallBrewCask:
-		return RunBrewCommand("install", "--cask", t.Formula)
+		return brewCommand("install", "--cask", t.Formula), nil
 	case InstallNpm:
-		return ExecCommand("npm", "install", "-g", t.Formula)
+		return []string{"npm", "install", "-g", t.Formula}, nil
 	case InstallBun:
-		return ExecCommand("bun", "install", "-g", t.Formula)
+		return []string{"bun", "install", "-g", t.Formula}, nil
+	case InstallMAS:
+		if t.AppStoreID == 0 {
+			return nil, fmt.Errorf("cannot auto-install %s (no App Store id)", t.Name)
+		}
+		return []string{"mas", "install", fmt.Sprint(t.AppStoreID)}, nil
+	case InstallCargo:
+		return []string{"cargo", "install", "--locked", t.Formula}, nil
+	case InstallUv:
+		return []string{"uv", "tool", "install", t.Formula}, nil
+	case InstallPipx:
+		return []string{"pipx", "install", t.Formula}, nil
+	case InstallGo:
+		return []string{"go", "install", t.goInstallTarget()}, nil
+	case InstallApt:
+		return []string{"sudo", "apt-get", "install", "-y", t.Formula}, nil
+	case InstallDnf:
+		return []string{"sudo", "dnf", "install", "-y", t.Formula}, nil
+	case InstallPacman:
+		return []string{"sudo", "pacman", "-S", "--noconfirm", t.Formula}, nil
 	default:
-		return fmt.Errorf("cannot auto-install %s (method: %s)", t.Name, t.Method)
+		return nil, fmt.Errorf("cannot auto-install %s (method: %s)", t.Name, t.Method)
 	}
 }
 
-// RunBrewCommand runs a brew command with ARM architecture forced
+// Uninstall removes the tool
+func (t Tool) Uninstall() error {
+	defer tool.ResetBrewInventory()
+	if t.UninstallFn != nil {
+		return t.UninstallFn()
+	}
+	if t.Method == InstallRelease {
+		return t.uninstallRelease()
+	}
+	if t.Method == InstallGo {
+		return t.uninstallGoBinary()
+	}
+
+	args, err := t.uninstallCommand()
+	if err != nil {
+		return err
+	}
+	return tool.RunLogged(args[0], args[1:]...)
+}
+
+// uninstallCommand returns the command line removing the tool with its Method
+func (t Tool) uninstallCommand() ([]string, error) {
+	switch t.Method {
+	case InstallBrewFormula:
+		return brewCommand("uninstall", t.Formula), nil
+	case InstallBrewCask:
+		return brewCommand("uninstall", "--cask", "--zap", t.Formula), nil
+	case InstallNpm:
+		return []string{"npm", "uninstall", "-g", t.Formula}, nil
+	case InstallBun:
+		return []string{"bun", "remove", "-g", t.Formula}, nil
+	case InstallCargo:
+		return []string{"cargo", "uninstall", t.Formula}, nil
+	case InstallUv:
+		return []string{"uv", "tool", "uninstall", t.Formula}, nil
+	case InstallPipx:
+		return []string{"pipx", "uninstall", t.Formula}, nil
+	case InstallApt:
+		return []string{"sudo", "apt-get", "remove", "-y", t.Formula}, nil
+	case InstallDnf:
+		return []string{"sudo", "dnf", "remove", "-y", t.Formula}, nil
+	case InstallPacman:
+		return []string{"sudo", "pacman", "-R", "--noconfirm", t.Formula}, nil
+	default:
+		return nil, fmt.Errorf("cannot auto-uninstall %s (method: %s)", t.Name, t.Method)
+	}
+}
+
+// GetInstalledDependents returns the installed tools that list name in their Dependencies
+func GetInstalledDependents(name string) []Tool {
+	var result []Tool
+	for _, t := range Tools {
+		for _, dep := range t.Dependencies {
+			if dep == name && t.Check().Installed {
+				result = append(result, t)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// RunBrewCommand runs a brew command (ARM architecture forced on Apple Silicon)
 func RunBrewCommand(args ...string) error {
-	cmd := exec.Command("arch", append([]string{"-arm64", "brew"}, args...)...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
+	defer tool.ResetBrewInventory()
+	cmd := brewCommand(args...)
+	return tool.RunLogged(cmd[0], cmd[1:]...)
+}
+
+// brewCommand returns the command line of a brew command. On Apple Silicon
+// brew is forced to ARM so a Rosetta shell never installs x86 bottles; Linuxbrew
+// and Intel Macs run brew directly.
+func brewCommand(args ...string) []string {
+	if p := CurrentPlatform(); p.IsMac() && p.Arch == "arm64" {
+		return append([]string{"arch", "-arm64", "brew"}, args...)
+	}
+	return append([]string{"brew"}, args...)
 }
@@ -0,0 +1,316 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+// useAppleSilicon pins the platform so brew commands get the arch wrapper
+func useAppleSilicon(t *testing.T) {
+	t.Helper()
+	t.Cleanup(SetPlatform(Platform{OS: "darwin", Arch: "arm64"}))
+}
+
+func TestToolInstallCommands(t *testing.T) {
+	useAppleSilicon(t)
+	tests := []struct {
+		name     string
+		given    Tool
+		expected string
+	}{
+		{"brew formula", Tool{Name: "go", Method: InstallBrewFormula, Formula: "go"}, "arch -arm64 brew install go"},
+		{"brew cask", Tool{Name: "zed", Method: InstallBrewCask, Formula: "zed"}, "arch -arm64 brew install --cask zed"},
+		{"npm", Tool{Name: "eas", Method: InstallNpm, Formula: "eas-cli"}, "npm install -g eas-cli"},
+		{"bun", Tool{Name: "codex", Method: InstallBun, Formula: "@openai/codex"}, "bun install -g @openai/codex"},
+		{"mas", Tool{Name: "xcode", Method: InstallMAS, AppStoreID: 497799835}, "mas install 497799835"},
+		{"cargo", Tool{Name: "ripgrep", Method: InstallCargo, Formula: "ripgrep"}, "cargo install --locked ripgrep"},
+		{"uv", Tool{Name: "ruff", Method: InstallUv, Formula: "ruff"}, "uv tool install ruff"},
+		{"pipx", Tool{Name: "poetry", Method: InstallPipx, Formula: "poetry"}, "pipx install poetry"},
+		{"go", Tool{Name: "gopls", Method: InstallGo, Formula: "golang.org/x/tools/gopls"}, "go install golang.org/x/tools/gopls@latest"},
+		{"go pinned", Tool{Name: "gopls", Method: InstallGo, Formula: "golang.org/x/tools/gopls@v0.16.2"}, "go install golang.org/x/tools/gopls@v0.16.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := tool.NewFakeRunner()
+			t.Cleanup(tool.SetRunner(fake))
+
+			if err := tt.given.Install(); err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+			got := fake.Commands()
+			if len(got) != 1 || got[0] != tt.expected {
+				t.Errorf("Install() ran %v, want [%s]", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToolInstallManualFails(t *testing.T) {
+	fake := tool.NewFakeRunner()
+	t.Cleanup(tool.SetRunner(fake))
+
+	if err := (Tool{Name: "xcode", Method: InstallXcode}).Install(); err == nil {
+		t.Fatal("expected an error for a tool without an installer")
+	}
+	if len(fake.Commands()) != 0 {
+		t.Errorf("no command should run, got %v", fake.Commands())
+	}
+}
+
+func TestToolCheck(t *testing.T) {
+	fake := tool.NewFakeRunner().
+		WithPath("go").
+		On("go version", tool.FakeResponse{Stdout: "go version go1.24.1 darwin/arm64"})
+	t.Cleanup(tool.SetRunner(fake))
+
+	goTool := Tool{Name: "go", Command: "go", VersionFn: tool.VersionFromCmd("go", []string{"version"}, tool.ParseGoVersion)}
+	if got := goTool.Check(); !got.Installed || got.Version != "1.24.1" {
+		t.Errorf("Check() = %+v, want installed 1.24.1", got)
+	}
+
+	missing := Tool{Name: "bun", Command: "bun"}
+	if got := missing.Check(); got.Installed {
+		t.Errorf("Check() = %+v, want not installed", got)
+	}
+}
+
+func TestToolchainCheck(t *testing.T) {
+	goBin := t.TempDir()
+	t.Setenv("GOBIN", goBin)
+	if err := os.WriteFile(filepath.Join(goBin, "staticcheck"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fake := tool.NewFakeRunner().
+		WithPath("rg").
+		On("cargo install --list", tool.FakeResponse{Stdout: "ripgrep v14.1.0:\n    rg\n"}).
+		On("uv tool list", tool.FakeResponse{Stdout: "ruff v0.6.9\n- ruff\n"}).
+		On("pipx list --short", tool.FakeResponse{Stdout: "black 24.8.0\n"}).
+		On("go version -m "+filepath.Join(goBin, "staticcheck"), tool.FakeResponse{
+			Stdout: "staticcheck: go1.24.0\n\tpath\thonnef.co/go/tools/cmd/staticcheck\n\tmod\thonnef.co/go/tools\tv0.5.1\th1:x=\n",
+		})
+	t.Cleanup(tool.SetRunner(fake))
+
+	tests := []struct {
+		name     string
+		given    Tool
+		expected CheckResult
+	}{
+		{"cargo with command", Tool{Name: "ripgrep", Command: "rg", Method: InstallCargo, Formula: "ripgrep"}, CheckResult{Installed: true, Version: "14.1.0"}},
+		{"uv", Tool{Name: "ruff", Method: InstallUv, Formula: "ruff"}, CheckResult{Installed: true, Version: "0.6.9"}},
+		{"uv missing", Tool{Name: "mypy", Method: InstallUv, Formula: "mypy"}, CheckResult{}},
+		{"pipx", Tool{Name: "black", Method: InstallPipx, Formula: "black"}, CheckResult{Installed: true, Version: "24.8.0"}},
+		{"go", Tool{Name: "staticcheck", Method: InstallGo, Formula: "honnef.co/go/tools/cmd/staticcheck"}, CheckResult{Installed: true, Version: "0.5.1"}},
+		{"go missing", Tool{Name: "gopls", Method: InstallGo, Formula: "golang.org/x/tools/gopls/v2"}, CheckResult{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.given.Check(); got != tt.expected {
+				t.Errorf("Check() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAppStoreCheck(t *testing.T) {
+	fake := tool.NewFakeRunner().
+		WithPath("mas").
+		On("mas list", tool.FakeResponse{Stdout: "409201541   Pages   (14.3)\n497799835   Xcode   (16.2)\n"})
+	t.Cleanup(tool.SetRunner(fake))
+
+	tests := []struct {
+		name     string
+		given    Tool
+		expected CheckResult
+	}{
+		{"listed by mas", Tool{Name: "xcode", Method: InstallMAS, AppStoreID: 497799835, App: "Xcode"}, CheckResult{Installed: true, Version: "16.2"}},
+		{"not listed, no bundle", Tool{Name: "final-cut-pro", Method: InstallMAS, AppStoreID: 424389933, App: "Final Cut Pro Missing"}, CheckResult{}},
+		{"no id, no bundle", Tool{Name: "broadcasts", Method: InstallMAS, App: "Broadcasts Missing"}, CheckResult{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.given.Check(); got != tt.expected {
+				t.Errorf("Check() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSecurityAndIdentityChecks(t *testing.T) {
+	fake := tool.NewFakeRunner().
+		On("fdesetup status", tool.FakeResponse{Stdout: "FileVault is On.\n"}).
+		On("csrutil status", tool.FakeResponse{Stdout: "System Integrity Protection status: disabled.\n"}).
+		On("git config --global user.email", tool.FakeResponse{Stdout: UserEmail + "\n"})
+	t.Cleanup(tool.SetRunner(fake))
+
+	tests := []struct {
+		name     string
+		checkFn  func() CheckResult
+		expected bool
+	}{
+		{"filevault on", findSecurityCheck(t, "filevault").CheckFn, true},
+		{"sip disabled", findSecurityCheck(t, "sip").CheckFn, false},
+		{"git email matches", findIdentityCheck(t, "git-email").CheckFn, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.checkFn().Installed; got != tt.expected {
+				t.Errorf("Installed = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func findSecurityCheck(t *testing.T, name string) SecurityCheck {
+	t.Helper()
+	for _, c := range SecurityChecks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("security check %q not found", name)
+	return SecurityCheck{}
+}
+
+func findIdentityCheck(t *testing.T, name string) IdentityCheck {
+	t.Helper()
+	for _, c := range IdentityChecks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("identity check %q not found", name)
+	return IdentityCheck{}
+}
+
+func TestToolUninstallCommands(t *testing.T) {
+	useAppleSilicon(t)
+	tests := []struct {
+		name     string
+		given    Tool
+		expected string
+	}{
+		{"brew formula", Tool{Name: "go", Method: InstallBrewFormula, Formula: "go"}, "arch -arm64 brew uninstall go"},
+		{"brew cask", Tool{Name: "zed", Method: InstallBrewCask, Formula: "zed"}, "arch -arm64 brew uninstall --cask --zap zed"},
+		{"npm", Tool{Name: "eas", Method: InstallNpm, Formula: "eas-cli"}, "npm uninstall -g eas-cli"},
+		{"bun", Tool{Name: "codex", Method: InstallBun, Formula: "@openai/codex"}, "bun remove -g @openai/codex"},
+		{"cargo", Tool{Name: "ripgrep", Method: InstallCargo, Formula: "ripgrep"}, "cargo uninstall ripgrep"},
+		{"uv", Tool{Name: "ruff", Method: InstallUv, Formula: "ruff"}, "uv tool uninstall ruff"},
+		{"pipx", Tool{Name: "poetry", Method: InstallPipx, Formula: "poetry"}, "pipx uninstall poetry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := tool.NewFakeRunner()
+			t.Cleanup(tool.SetRunner(fake))
+
+			if err := tt.given.Uninstall(); err != nil {
+				t.Fatalf("Uninstall() error = %v", err)
+			}
+			got := fake.Commands()
+			if len(got) != 1 || got[0] != tt.expected {
+				t.Errorf("Uninstall() ran %v, want [%s]", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetInstalledDependents(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "bun", Command: "bun"},
+		{Name: "codex", Command: "codex", Dependencies: []string{"bun"}},
+		{Name: "gemini", Command: "gemini", Dependencies: []string{"bun"}},
+	}
+
+	fake := tool.NewFakeRunner().WithPath("bun", "codex")
+	t.Cleanup(tool.SetRunner(fake))
+
+	got := GetInstalledDependents("bun")
+	if len(got) != 1 || got[0].Name != "codex" {
+		t.Errorf("GetInstalledDependents() = %v, want [codex]", got)
+	}
+}
+
+func TestToolTooOld(t *testing.T) {
+	tests := []struct {
+		name       string
+		minVersion string
+		version    string
+		expected   bool
+	}{
+		{"no minimum", "", "1.0.0", false},
+		{"bare minimum met", "1.24", "1.24.2", false},
+		{"bare minimum failed", "1.24", "1.23.4", true},
+		{"go prefix", "1.24", "go1.22", true},
+		{"constraint range", ">=1.2 <2", "2.1.0", true},
+		{"java", "21", "21.0.2", false},
+		{"unknown version", "1.24", "", false},
+		{"unparsable version", "1.24", "dev", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given := Tool{Name: "tool", MinVersion: tt.minVersion}
+			if got := given.TooOld(tt.version); got != tt.expected {
+				t.Errorf("TooOld(%q) with MinVersion %q = %v, expected %v", tt.version, tt.minVersion, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindTool(t *testing.T) {
+	original := Tools
+	t.Cleanup(func() { Tools = original })
+	Tools = []Tool{
+		{Name: "homebrew", Aliases: []string{"brew"}, Command: "brew"},
+		{Name: "openjdk", Aliases: []string{"java"}, Command: "java"},
+		{Name: "python", Aliases: []string{"python3"}, Command: "python3"},
+		{Name: "orbstack", Aliases: []string{"docker"}, Command: "orb"},
+		{Name: "codex", Command: "codex"},
+		{Name: "cocoapods", Command: "pod"},
+	}
+
+	tests := []struct {
+		given    string
+		expected string // Tool name, or the error message
+	}{
+		{"openjdk", "openjdk"},
+		{"java", "openjdk"},
+		{"brew", "homebrew"},
+		{"python3", "python"},
+		{"docker", "orbstack"},
+		{"jav", "unknown tool: jav. Did you mean openjdk?"},
+		{"pyhton", "unknown tool: pyhton. Did you mean python?"},
+		{"dokcer", "unknown tool: dokcer. Did you mean orbstack?"},
+		{"codx", "unknown tool: codx. Did you mean codex?"},
+		{"pods", "unknown tool: pods. Did you mean cocoapods?"},
+		{"kubernetes", "unknown tool: kubernetes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			found, err := FindTool(tt.given)
+			got := ""
+			if err != nil {
+				if !errors.Is(err, ErrUnknownItem) {
+					t.Errorf("FindTool(%q) error %v is not ErrUnknownItem", tt.given, err)
+				}
+				got = err.Error()
+			} else {
+				got = found.Name
+			}
+			if got != tt.expected {
+				t.Errorf("FindTool(%q) = %q, expected %q", tt.given, got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,38 +2,106 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 	output "github.com/jterrazz/jterrazz-cli/src/internal/presentation/print"
 )
 
 // PackageManager represents an upgradable package manager
 type PackageManager struct {
 	Name        string
-	Flag        string // CLI flag name (e.g., "brew" for --brew)
-	RequiresCmd string // Command that must exist
-	UpgradeFn   func() // Function to run upgrades
+	Flag        string       // CLI flag name (e.g., "brew" for --brew)
+	RequiresCmd string       // Command that must exist
+	Commands    [][]string   // Commands run by UpgradeFn, in order
+	UpgradeFn   func() error // Function to run upgrades
+
+	CommandsFn func() [][]string // Builds Commands from the installed packages, when they depend on them
+
+	OutdatedFn func() ([]OutdatedPackage, error) // Lists packages with a newer version
 }
 
+// Upgrade commands are declared apart from PackageManagers so the upgrade
+// functions can reference them without an initialization cycle
+var (
+	brewUpgradeCommands = [][]string{{"brew", "update"}, {"brew", "upgrade"}}
+	npmUpgradeCommands  = [][]string{{"npm", "update", "-g"}}
+	bunUpgradeCommands  = [][]string{{"bun", "update", "-g"}}
+	uvUpgradeCommands   = [][]string{{"uv", "tool", "upgrade", "--all"}}
+	pipxUpgradeCommands = [][]string{{"pipx", "upgrade-all"}}
+	masUpgradeCommands  = [][]string{{"mas", "upgrade"}}
+)
+
 // PackageManagers is the list of all package managers that can be upgraded
 var PackageManagers = []PackageManager{
 	{
 		Name:        "homebrew",
 		Flag:        "brew",
 		RequiresCmd: "brew",
+		Commands:    brewUpgradeCommands,
 		UpgradeFn:   upgradeBrew,
+		OutdatedFn:  outdatedBrew,
 	},
 	{
 		Name:        "npm",
 		Flag:        "npm",
 		RequiresCmd: "npm",
+		Commands:    npmUpgradeCommands,
 		UpgradeFn:   upgradeNpm,
+		OutdatedFn:  outdatedNpm,
 	},
 	{
 		Name:        "bun",
 		Flag:        "bun",
 		RequiresCmd: "bun",
+		Commands:    bunUpgradeCommands,
 		UpgradeFn:   upgradeBun,
+		OutdatedFn:  outdatedBun,
 	},
+	{
+		Name:        "cargo",
+		Flag:        "cargo",
+		RequiresCmd: "cargo",
+		CommandsFn:  cargoUpgradeCommands,
+		UpgradeFn:   upgradeCargo,
+	},
+	{
+		Name:        "uv",
+		Flag:        "uv",
+		RequiresCmd: "uv",
+		Commands:    uvUpgradeCommands,
+		UpgradeFn:   upgradeUv,
+	},
+	{
+		Name:        "pipx",
+		Flag:        "pipx",
+		RequiresCmd: "pipx",
+		Commands:    pipxUpgradeCommands,
+		UpgradeFn:   upgradePipx,
+	},
+	{
+		Name:        "mas",
+		Flag:        "mas",
+		RequiresCmd: "mas",
+		Commands:    masUpgradeCommands,
+		UpgradeFn:   upgradeMas,
+		OutdatedFn:  outdatedMas,
+	},
+	{
+		Name:        "go",
+		Flag:        "go",
+		RequiresCmd: "go",
+		CommandsFn:  goUpgradeCommands,
+		UpgradeFn:   upgradeGo,
+	},
+}
+
+// UpgradeCommands returns the commands UpgradeFn runs
+func (pm PackageManager) UpgradeCommands() [][]string {
+	if pm.CommandsFn != nil {
+		return pm.CommandsFn()
+	}
+	return pm.Commands
 }
 
 // GetPackageManagerByFlag returns a package manager by its flag name
@@ -46,95 +114,205 @@ func GetPackageManagerByFlag(flag string) *PackageManager {
 	return nil
 }
 
-// UpgradeAll upgrades all available package managers
-func UpgradeAll() {
+// UpgradeAll upgrades all available package managers. A failing one is
+// reported and does not stop the following ones.
+func UpgradeAll() error {
+	var errs []error
+	total := 0
 	for _, pm := range PackageManagers {
-		if CommandExists(pm.RequiresCmd) {
-			pm.UpgradeFn()
+		if !CommandExists(pm.RequiresCmd) {
+			continue
+		}
+		total++
+		if err := pm.UpgradeFn(); err != nil {
+			err = fmt.Errorf("%s upgrade failed: %w", pm.Name, err)
+			output.Error(err.Error())
+			errs = append(errs, err)
 		}
 	}
+	return NewBatchError(total, errs)
 }
 
 // UpgradePackageManager upgrades a specific package manager
-func UpgradePackageManager(pm PackageManager) {
+func UpgradePackageManager(pm PackageManager) error {
 	if !CommandExists(pm.RequiresCmd) {
-		fmt.Printf("%s %s not found, skipping\n", output.Yellow("Warning:"), pm.RequiresCmd)
-		return
+		return MissingDependencyError(pm.RequiresCmd + " not found")
+	}
+	if err := pm.UpgradeFn(); err != nil {
+		return fmt.Errorf("%s upgrade failed: %w", pm.Name, err)
 	}
-	pm.UpgradeFn()
+	return nil
 }
 
-// UpgradePackageByName upgrades a specific package by name
+// UpgradePackageByName upgrades a specific package by name. Names outside the
+// registry are upgraded with brew only when brew has them installed; otherwise
+// the FindTool error (with its suggestions) is returned.
 func UpgradePackageByName(name string) error {
-	// Find package in our tools list
-	pkg := GetToolByName(name)
-	if pkg != nil {
-		switch pkg.Method {
-		case InstallBrewFormula:
-			if !CommandExists("brew") {
-				return fmt.Errorf("Homebrew not found")
-			}
-			fmt.Printf("  📥 Upgrading %s...\n", name)
-			ExecCommand("brew", "upgrade", pkg.Formula)
-			fmt.Printf("  %s %s upgraded\n", output.Green("✓"), name)
-			return nil
-		case InstallBrewCask:
-			if !CommandExists("brew") {
-				return fmt.Errorf("Homebrew not found")
-			}
-			fmt.Printf("  📥 Upgrading %s...\n", name)
-			ExecCommand("brew", "upgrade", "--cask", pkg.Formula)
-			fmt.Printf("  %s %s upgraded\n", output.Green("✓"), name)
-			return nil
-		case InstallNpm:
-			if !CommandExists("npm") {
-				return fmt.Errorf("npm not found")
-			}
-			fmt.Printf("  📥 Upgrading %s...\n", name)
-			ExecCommand("npm", "update", "-g", pkg.Formula)
-			fmt.Printf("  %s %s upgraded\n", output.Green("✓"), name)
-			return nil
-		case InstallBun:
-			if !CommandExists("bun") {
-				return fmt.Errorf("bun not found")
-			}
-			fmt.Printf("  📥 Upgrading %s...\n", name)
-			ExecCommand("bun", "update", "-g", pkg.Formula)
-			fmt.Printf("  %s %s upgraded\n", output.Green("✓"), name)
-			return nil
+	pkg, err := FindTool(name)
+	if err != nil {
+		if args, ok := installedBrewUpgrade(name); ok {
+			return upgradePackage(name, args...)
 		}
+		return err
 	}
 
-	// Try as a direct brew package name
-	if CommandExists("brew") {
-		fmt.Printf("  📥 Upgrading %s...\n", name)
-		ExecCommand("brew", "upgrade", name)
-		fmt.Printf("  %s %s upgraded\n", output.Green("✓"), name)
-		return nil
+	args, routeErr := pkg.UpgradeCommand()
+	if routeErr != nil {
+		// Tools installed another way may still be brew packages (e.g. node)
+		if args, ok := installedBrewUpgrade(pkg.Name); ok && pkg.Method != InstallMAS {
+			return upgradePackage(pkg.Name, args...)
+		}
+		return routeErr
 	}
+	if !CommandExists(args[0]) {
+		if args[0] == "brew" {
+			return MissingDependencyError("Homebrew not found")
+		}
+		return MissingDependencyError(args[0] + " not found")
+	}
+	return upgradePackage(pkg.Name, args...)
+}
 
-	return fmt.Errorf("unknown package: %s", name)
+// installedBrewUpgrade returns the command upgrading name with brew, when brew
+// has it installed as a formula or a cask
+func installedBrewUpgrade(name string) ([]string, bool) {
+	if !CommandExists("brew") {
+		return nil, false
+	}
+	inv, err := tool.Brew()
+	if err != nil {
+		return nil, false
+	}
+	if _, ok := inv.Formula(name); ok {
+		return []string{"brew", "upgrade", name}, true
+	}
+	if _, ok := inv.Cask(name); ok {
+		return []string{"brew", "upgrade", "--cask", name}, true
+	}
+	return nil, false
+}
+
+// UpgradeCommand returns the command line upgrading the tool alone, with the
+// package manager that installed it
+func (t Tool) UpgradeCommand() ([]string, error) {
+	switch t.Method {
+	case InstallBrewFormula:
+		return []string{"brew", "upgrade", t.Formula}, nil
+	case InstallBrewCask:
+		return []string{"brew", "upgrade", "--cask", t.Formula}, nil
+	case InstallNpm:
+		return []string{"npm", "update", "-g", t.Formula}, nil
+	case InstallBun:
+		return []string{"bun", "update", "-g", t.Formula}, nil
+	case InstallCargo:
+		return []string{"cargo", "install", "--locked", t.Formula}, nil
+	case InstallUv:
+		return []string{"uv", "tool", "upgrade", t.Formula}, nil
+	case InstallPipx:
+		return []string{"pipx", "upgrade", t.Formula}, nil
+	case InstallMAS:
+		if t.AppStoreID == 0 {
+			return nil, fmt.Errorf("cannot upgrade %s (no App Store id)", t.Name)
+		}
+		return []string{"mas", "upgrade", fmt.Sprint(t.AppStoreID)}, nil
+	case InstallGo:
+		return []string{"go", "install", t.goPackage() + "@latest"}, nil
+	}
+	return nil, fmt.Errorf("cannot upgrade %s (method: %s)", t.Name, t.Method)
+}
+
+// upgradePackage runs the upgrade command of a single package
+func upgradePackage(name string, args ...string) error {
+	fmt.Printf("  📥 Upgrading %s...\n", name)
+	if err := tool.RunLogged(args[0], args[1:]...); err != nil {
+		return fmt.Errorf("failed to upgrade %s: %w", name, err)
+	}
+	fmt.Printf("  %s %s upgraded\n", output.Green("✓"), name)
+	return nil
 }
 
 // =============================================================================
 // Upgrade Functions
 // =============================================================================
 
-func upgradeBrew() {
+func upgradeBrew() error {
 	fmt.Println(output.Cyan("🍺 Upgrading Homebrew packages..."))
-	ExecCommand("brew", "update")
-	ExecCommand("brew", "upgrade")
+	if err := runCommands(brewUpgradeCommands); err != nil {
+		return err
+	}
 	fmt.Println(output.Green("  ✅ Homebrew upgrade completed"))
+	return nil
 }
 
-func upgradeNpm() {
+func upgradeNpm() error {
 	fmt.Println(output.Cyan("📦 Upgrading npm global packages..."))
-	ExecCommand("npm", "update", "-g")
+	if err := runCommands(npmUpgradeCommands); err != nil {
+		return err
+	}
 	fmt.Println(output.Green("  ✅ npm upgrade completed"))
+	return nil
 }
 
-func upgradeBun() {
+func upgradeBun() error {
 	fmt.Println(output.Cyan("📦 Upgrading bun global packages..."))
-	ExecCommand("bun", "update", "-g")
+	if err := runCommands(bunUpgradeCommands); err != nil {
+		return err
+	}
 	fmt.Println(output.Green("  ✅ bun upgrade completed"))
+	return nil
+}
+
+func upgradeCargo() error {
+	fmt.Println(output.Cyan("🦀 Upgrading cargo crates..."))
+	if err := runCommands(cargoUpgradeCommands()); err != nil {
+		return err
+	}
+	fmt.Println(output.Green("  ✅ cargo upgrade completed"))
+	return nil
+}
+
+func upgradeUv() error {
+	fmt.Println(output.Cyan("🐍 Upgrading uv tools..."))
+	if err := runCommands(uvUpgradeCommands); err != nil {
+		return err
+	}
+	fmt.Println(output.Green("  ✅ uv upgrade completed"))
+	return nil
+}
+
+func upgradePipx() error {
+	fmt.Println(output.Cyan("🐍 Upgrading pipx packages..."))
+	if err := runCommands(pipxUpgradeCommands); err != nil {
+		return err
+	}
+	fmt.Println(output.Green("  ✅ pipx upgrade completed"))
+	return nil
+}
+
+func upgradeMas() error {
+	fmt.Println(output.Cyan("🍏 Upgrading Mac App Store apps..."))
+	if err := runCommands(masUpgradeCommands); err != nil {
+		return err
+	}
+	fmt.Println(output.Green("  ✅ mas upgrade completed"))
+	return nil
+}
+
+func upgradeGo() error {
+	fmt.Println(output.Cyan("🐹 Upgrading go binaries..."))
+	if err := runCommands(goUpgradeCommands()); err != nil {
+		return err
+	}
+	fmt.Println(output.Green("  ✅ go upgrade completed"))
+	return nil
+}
+
+// runCommands runs each command in order, attached to the terminal and kept in the run log, and stops at the first failure
+func runCommands(commands [][]string) error {
+	for _, args := range commands {
+		if err := tool.RunLogged(args[0], args[1:]...); err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(args, " "), err)
+		}
+	}
+	return nil
 }
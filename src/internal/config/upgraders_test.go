@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
+)
+
+func TestUpgradeErrors(t *testing.T) {
+	failure := tool.FakeResponse{Err: errors.New("exit status 1")}
+	tests := []struct {
+		name    string
+		fake    *tool.FakeRunner
+		upgrade func() error
+		wantErr bool
+		kind    error // Expected error kind, nil for any
+		partial bool
+	}{
+		{
+			name:    "all package managers succeed",
+			fake:    tool.NewFakeRunner().WithPath("brew", "npm"),
+			upgrade: UpgradeAll,
+		},
+		{
+			name:    "one package manager fails",
+			fake:    tool.NewFakeRunner().WithPath("brew", "npm").On("npm update -g", failure),
+			upgrade: UpgradeAll,
+			wantErr: true,
+			partial: true,
+		},
+		{
+			name:    "brew update fails",
+			fake:    tool.NewFakeRunner().WithPath("brew").On("brew update", failure),
+			upgrade: func() error { return UpgradePackageManager(*GetPackageManagerByFlag("brew")) },
+			wantErr: true,
+		},
+		{
+			name:    "package manager not installed",
+			fake:    tool.NewFakeRunner(),
+			upgrade: func() error { return UpgradePackageManager(*GetPackageManagerByFlag("bun")) },
+			wantErr: true,
+			kind:    ErrMissingDependency,
+		},
+		{
+			name: "cargo reinstalls crates.io crates",
+			fake: tool.NewFakeRunner().WithPath("cargo").
+				On("cargo install --list", tool.FakeResponse{Stdout: "ripgrep v14.1.0:\n    rg\nbat v0.24.0:\n    bat\n"}).
+				On("cargo install --locked bat ripgrep", failure),
+			upgrade: func() error { return UpgradePackageManager(*GetPackageManagerByFlag("cargo")) },
+			wantErr: true,
+		},
+		{
+			name:    "uv tool not installed",
+			fake:    tool.NewFakeRunner().WithPath("brew"),
+			upgrade: func() error { return UpgradePackageManager(*GetPackageManagerByFlag("uv")) },
+			wantErr: true,
+			kind:    ErrMissingDependency,
+		},
+		{
+			name:    "unknown package without brew",
+			fake:    tool.NewFakeRunner(),
+			upgrade: func() error { return UpgradePackageByName("not-a-tool") },
+			wantErr: true,
+			kind:    ErrUnknownItem,
+		},
+		{
+			name:    "unknown package brew does not have",
+			fake:    tool.NewFakeRunner().WithPath("brew").On("brew info --json=v2 --installed", tool.FakeResponse{Stdout: `{"formulae": [], "casks": []}`}),
+			upgrade: func() error { return UpgradePackageByName("not-a-tool") },
+			wantErr: true,
+			kind:    ErrUnknownItem,
+		},
+		{
+			name: "package upgrade fails",
+			fake: tool.NewFakeRunner().WithPath("brew").
+				On("brew info --json=v2 --installed", tool.FakeResponse{Stdout: `{"formulae": [{"name": "not-a-tool", "full_name": "not-a-tool", "installed": [{"version": "1.0"}]}], "casks": []}`}).
+				On("brew upgrade not-a-tool", failure),
+			upgrade: func() error { return UpgradePackageByName("not-a-tool") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(tool.SetRunner(tt.fake))
+			err := tt.upgrade()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.kind != nil && !errors.Is(err, tt.kind) {
+				t.Errorf("error %q is not %v", err, tt.kind)
+			}
+			var batch *BatchError
+			if partial := errors.As(err, &batch) && batch.Partial(); partial != tt.partial {
+				t.Errorf("partial = %v, expected %v (%v)", partial, tt.partial, err)
+			}
+		})
+	}
+}
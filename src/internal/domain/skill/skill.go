@@ -1,8 +1,8 @@
 package skill
 
 import (
+	"bytes"
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
@@ -10,8 +10,7 @@ import (
 
 // Install installs a skill from a repo globally
 func Install(repo, skill string) error {
-	cmd := exec.Command("skills", "add", repo, "-g", "-y", "--skill", skill)
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := runLogged("add", repo, "-g", "-y", "--skill", skill); err != nil {
 		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
 	}
 	return nil
@@ -19,8 +18,7 @@ func Install(repo, skill string) error {
 
 // InstallAll installs all skills from a repo globally
 func InstallAll(repo string) error {
-	cmd := exec.Command("skills", "add", repo, "-g", "-y", "--all")
-	if output, err := cmd.CombinedOutput(); err != nil {
+	if output, err := runLogged("add", repo, "-g", "-y", "--all"); err != nil {
 		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
 	}
 	return nil
@@ -28,8 +26,7 @@ func InstallAll(repo string) error {
 
 // Remove removes a skill globally
 func Remove(skill string) error {
-	cmd := exec.Command("skills", "remove", "-g", "-y", skill)
-	if err := cmd.Run(); err != nil {
+	if _, err := runLogged("remove", "-g", "-y", skill); err != nil {
 		return fmt.Errorf("failed to remove %s: %w", skill, err)
 	}
 	return nil
@@ -37,19 +34,25 @@ func Remove(skill string) error {
 
 // RemoveAll removes all skills globally
 func RemoveAll() error {
-	cmd := exec.Command("skills", "remove", "-g", "-y", "--all")
-	if err := cmd.Run(); err != nil {
+	if _, err := runLogged("remove", "-g", "-y", "--all"); err != nil {
 		return fmt.Errorf("failed to remove all skills: %w", err)
 	}
 	return nil
 }
 
+// runLogged runs a skills command changing the installed skills, keeping it in
+// the run log, and returns its combined output
+func runLogged(args ...string) ([]byte, error) {
+	var out bytes.Buffer
+	err := tool.RunCmd(tool.Cmd{Name: "skills", Args: args, Stdout: &out, Stderr: &out, Logged: true})
+	return out.Bytes(), err
+}
+
 // ListInstalled returns the list of globally installed skill names
 func ListInstalled() []string {
 	var installed []string
 
-	cmd := exec.Command("skills", "list", "-g")
-	output, err := cmd.Output()
+	output, err := tool.Output("skills", "list", "-g")
 	if err != nil {
 		return installed
 	}
@@ -88,8 +91,7 @@ func ListInstalled() []string {
 
 // ListFromRepo fetches available skills from a repo
 func ListFromRepo(repo string) ([]string, error) {
-	cmd := exec.Command("skills", "add", repo, "--list")
-	output, err := cmd.CombinedOutput()
+	output, err := tool.CombinedOutput("skills", "add", repo, "--list")
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +100,6 @@ func ListFromRepo(repo string) ([]string, error) {
 
 // IsInstalled checks if the skills CLI is available
 func IsInstalled() bool {
-	_, err := exec.LookPath("skills")
+	_, err := tool.LookPath("skills")
 	return err == nil
 }
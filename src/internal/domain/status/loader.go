@@ -46,8 +46,14 @@ type Item struct {
 	Style     string // Semantic style: "success", "warning", "muted", etc.
 	GoodWhen  bool   // For checks: true means Installed=true is good
 	Method    string // Install method for tools
+	Latest    string // Newer version available for outdated tools
+	Required  string // MinVersion the installed version fails, for too-old tools
 	Available bool   // For resources: whether the resource exists
 
+	// Health of service-like tools: "healthy", "degraded" or "down" ("" when not probed)
+	Health       string
+	HealthReason string
+
 	// Process data (for KindProcess items)
 	Processes []config.ProcessInfo
 }
@@ -67,12 +73,29 @@ type Loader struct {
 	updates chan UpdateMsg
 	started bool
 	mu      sync.Mutex
+
+	cache   *config.CheckCache // Tool checks shown before they are revalidated
+	tools   []config.Tool      // Tools reported on
+	scripts []config.Script    // Setup scripts with a check reported on
 }
 
-// NewLoader creates a new loader with all items in pending state
-func NewLoader() *Loader {
+// NewLoader creates a new loader with all items in pending state, except the
+// tools with a cached check, which start loaded and are revalidated by Start.
+// A non-nil profile limits the tools and setup scripts to the ones it includes.
+func NewLoader(cache *config.CheckCache, profile *config.Profile) *Loader {
 	loader := &Loader{
 		updates: make(chan UpdateMsg, 100),
+		cache:   cache,
+	}
+	for _, t := range config.Tools {
+		if !t.Unsupported && (profile == nil || profile.IncludesTool(t)) {
+			loader.tools = append(loader.tools, t)
+		}
+	}
+	for _, script := range config.Scripts {
+		if script.CheckFn != nil && (profile == nil || profile.IncludesScript(script.Name)) {
+			loader.scripts = append(loader.scripts, script)
+		}
 	}
 	loader.buildItems()
 	return loader
@@ -110,10 +133,7 @@ func (l *Loader) buildItems() {
 
 	// Setup section (standalone)
 	l.addItem(Item{ID: "header-setup", Kind: KindHeader, Section: "Setup", SubSection: "Setup", Loaded: true})
-	for _, script := range config.Scripts {
-		if script.CheckFn == nil {
-			continue
-		}
+	for _, script := range l.scripts {
 		l.addItem(Item{
 			ID:          "setup-" + script.Name,
 			Kind:        KindSetup,
@@ -162,20 +182,23 @@ func (l *Loader) buildItems() {
 
 	// Tools sections
 	for _, category := range config.ToolCategories {
-		tools := config.GetToolsByCategory(category)
+		var tools []config.Tool
+		for _, t := range l.tools {
+			if t.Category == category {
+				tools = append(tools, t)
+			}
+		}
 		if len(tools) == 0 {
 			continue
 		}
 		l.addItem(Item{ID: "header-tools-" + string(category), Kind: KindHeader, Section: "Tools", SubSection: string(category), Loaded: true})
 		for _, t := range tools {
-			l.addItem(Item{
-				ID:         "tool-" + t.Name,
-				Kind:       KindTool,
-				Section:    "Tools",
-				SubSection: string(category),
-				Name:       t.Name,
-				Method:     t.Method.String(),
-			})
+			item := Item{Name: t.Name, Method: t.Method.String()}
+			if result, ok := l.cache.Peek(t); ok {
+				item = toolItem(t, result)
+			}
+			item.ID, item.Kind, item.Section, item.SubSection = "tool-"+t.Name, KindTool, "Tools", string(category)
+			l.addItem(item)
 		}
 	}
 
@@ -241,10 +264,7 @@ func (l *Loader) Start() {
 	}()
 
 	// Setup checks
-	for _, script := range config.Scripts {
-		if script.CheckFn == nil {
-			continue
-		}
+	for _, script := range l.scripts {
 		wg.Add(1)
 		go func(s config.Script) {
 			defer wg.Done()
@@ -343,25 +363,50 @@ func (l *Loader) Start() {
 		}(check)
 	}
 
-	// Tool checks
-	for _, t := range config.Tools {
+	// Tool checks. Outdated versions come from the package managers, which
+	// answer slower: tools already loaded are sent again with Latest set.
+	var (
+		toolsMu   sync.Mutex
+		toolItems = make(map[string]Item)
+		latest    map[string]string // nil until the package managers answered
+	)
+	for _, t := range l.tools {
 		wg.Add(1)
 		go func(t config.Tool) {
 			defer wg.Done()
-			result := t.Check()
-			item := Item{
-				ID:        "tool-" + t.Name,
-				Kind:      KindTool,
-				Name:      t.Name,
-				Loaded:    true,
-				Installed: result.Installed,
-				Version:   result.Version,
-				Status:    result.Status,
-				Method:    t.Method.String(),
+			item := toolItem(t, l.cache.Check(t))
+			if item.Installed && t.HealthFn != nil {
+				health := t.HealthFn()
+				item.Health, item.HealthReason = string(health.State), health.Reason
 			}
+			toolsMu.Lock()
+			item.Latest = latest[t.Name]
+			toolItems[t.Name] = item
+			toolsMu.Unlock()
 			l.updates <- UpdateMsg{ID: item.ID, Item: item}
 		}(t)
 	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		outdated, _ := config.CheckOutdated(l.tools)
+
+		var resend []Item
+		toolsMu.Lock()
+		latest = make(map[string]string, len(outdated))
+		for _, o := range outdated {
+			latest[o.Tool.Name] = o.Latest
+			if item, ok := toolItems[o.Tool.Name]; ok {
+				item.Latest = o.Latest
+				resend = append(resend, item)
+			}
+		}
+		toolsMu.Unlock()
+
+		for _, item := range resend {
+			l.updates <- UpdateMsg{ID: item.ID, Item: item}
+		}
+	}()
 
 	// Process checks
 	for _, check := range config.ProcessChecks {
@@ -422,10 +467,29 @@ func (l *Loader) Start() {
 	// Close channel when all done
 	go func() {
 		wg.Wait()
+		l.cache.Save()
 		close(l.updates)
 	}()
 }
 
+// toolItem builds the status item of a tool from its check result
+func toolItem(t config.Tool, result config.CheckResult) Item {
+	item := Item{
+		ID:        "tool-" + t.Name,
+		Kind:      KindTool,
+		Name:      t.Name,
+		Loaded:    true,
+		Installed: result.Installed,
+		Version:   result.Version,
+		Status:    result.Status,
+		Method:    t.Method.String(),
+	}
+	if result.Installed && t.TooOld(result.Version) {
+		item.Required = t.MinVersion
+	}
+	return item
+}
+
 // WaitForUpdate returns a command that waits for the next update
 func (l *Loader) WaitForUpdate() tea.Cmd {
 	return func() tea.Msg {
@@ -0,0 +1,190 @@
+package tool
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Archives - Download, verify and unpack release archives
+// =============================================================================
+
+// HTTPClient downloads release archives and checksum files
+var HTTPClient = &http.Client{Timeout: 10 * time.Minute}
+
+// DownloadFile saves url to dest
+func DownloadFile(url, dest string) error {
+	resp, err := HTTPClient.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return f.Close()
+}
+
+// FileSHA256 returns the hex SHA-256 of a file
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ParseChecksumFile finds the checksum of name in sha256sum output
+// ("<hex>  name" or "<hex> *name" per line)
+func ParseChecksumFile(s, name string) (string, bool) {
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+// ExtractFiles copies the archive members at paths (relative to the archive
+// root) into dir under their base name, executable. .tar.gz, .tgz and .zip
+// archives are supported.
+func ExtractFiles(archive string, paths []string, dir string) error {
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[path.Clean(p)] = true
+	}
+	found := make(map[string]bool, len(paths))
+
+	extract := func(name string, r io.Reader) error {
+		name = path.Clean(strings.TrimPrefix(name, "./"))
+		if !wanted[name] {
+			return nil
+		}
+		found[name] = true
+		return writeExecutable(filepath.Join(dir, path.Base(name)), r)
+	}
+
+	var err error
+	switch {
+	case strings.HasSuffix(archive, ".tar.gz"), strings.HasSuffix(archive, ".tgz"):
+		err = walkTarGz(archive, extract)
+	case strings.HasSuffix(archive, ".zip"):
+		err = walkZip(archive, extract)
+	default:
+		return fmt.Errorf("unsupported archive %s (expected .tar.gz, .tgz or .zip)", filepath.Base(archive))
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		if !found[path.Clean(p)] {
+			return fmt.Errorf("%s not found in %s", p, filepath.Base(archive))
+		}
+	}
+	return nil
+}
+
+func walkTarGz(archive string, fn func(name string, r io.Reader) error) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func walkZip(archive string, fn func(name string, r io.Reader) error) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(archive), err)
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = fn(file.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeExecutable writes r to dest through a temp file so a running binary is replaced atomically
+func writeExecutable(dest string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
@@ -0,0 +1,163 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+)
+
+// =============================================================================
+// Brew Inventory - One `brew info` for every formula and cask lookup
+// =============================================================================
+
+// BrewPackage is an installed formula or cask
+type BrewPackage struct {
+	Name     string // Formula name or cask token, without its tap
+	Version  string // Installed version (the linked keg, else the newest)
+	Latest   string // Version the tap offers
+	Outdated bool
+	Cask     bool
+}
+
+// BrewInventory is what brew has installed, as `brew info --json=v2 --installed` reports it
+type BrewInventory struct {
+	Formulae []BrewPackage
+	Casks    []BrewPackage
+
+	formulae map[string]int // Name, full name (tap/name) and aliases -> index in Formulae
+	casks    map[string]int // Token and full token -> index in Casks
+}
+
+// brewInfoFormula is one formula in `brew info --json=v2`
+type brewInfoFormula struct {
+	Name     string   `json:"name"`
+	FullName string   `json:"full_name"`
+	Aliases  []string `json:"aliases"`
+	Versions struct {
+		Stable string `json:"stable"`
+	} `json:"versions"`
+	Revision  int `json:"revision"`
+	Installed []struct {
+		Version string `json:"version"`
+	} `json:"installed"`
+	LinkedKeg string `json:"linked_keg"`
+	Outdated  bool   `json:"outdated"`
+}
+
+// brewInfoCask is one cask in `brew info --json=v2`
+type brewInfoCask struct {
+	Token     string `json:"token"`
+	FullToken string `json:"full_token"`
+	Version   string `json:"version"`
+	Installed string `json:"installed"`
+	Outdated  bool   `json:"outdated"`
+}
+
+// ParseBrewInventory parses the output of `brew info --json=v2 --installed`
+func ParseBrewInventory(data []byte) (*BrewInventory, error) {
+	var info struct {
+		Formulae []brewInfoFormula `json:"formulae"`
+		Casks    []brewInfoCask    `json:"casks"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse brew info: %w", err)
+	}
+
+	inv := &BrewInventory{formulae: make(map[string]int), casks: make(map[string]int)}
+	for _, f := range info.Formulae {
+		latest := f.Versions.Stable
+		if f.Revision > 0 {
+			latest += "_" + strconv.Itoa(f.Revision)
+		}
+		version := f.LinkedKeg
+		if version == "" && len(f.Installed) > 0 {
+			version = f.Installed[len(f.Installed)-1].Version
+		}
+		index := len(inv.Formulae)
+		inv.Formulae = append(inv.Formulae, BrewPackage{Name: f.Name, Version: version, Latest: latest, Outdated: f.Outdated})
+		for _, key := range append([]string{f.Name, f.FullName}, f.Aliases...) {
+			if _, taken := inv.formulae[key]; key != "" && !taken {
+				inv.formulae[key] = index
+			}
+		}
+	}
+	for _, c := range info.Casks {
+		index := len(inv.Casks)
+		inv.Casks = append(inv.Casks, BrewPackage{Name: c.Token, Version: c.Installed, Latest: c.Version, Outdated: c.Outdated, Cask: true})
+		for _, key := range []string{c.Token, c.FullToken} {
+			if _, taken := inv.casks[key]; key != "" && !taken {
+				inv.casks[key] = index
+			}
+		}
+	}
+	return inv, nil
+}
+
+// Formula returns the installed formula by name, tap-qualified name or alias
+func (inv *BrewInventory) Formula(name string) (BrewPackage, bool) {
+	return lookupBrewPackage(inv.Formulae, inv.formulae, name)
+}
+
+// Cask returns the installed cask by token or tap-qualified token
+func (inv *BrewInventory) Cask(token string) (BrewPackage, bool) {
+	return lookupBrewPackage(inv.Casks, inv.casks, token)
+}
+
+func lookupBrewPackage(packages []BrewPackage, index map[string]int, name string) (BrewPackage, bool) {
+	i, ok := index[name]
+	if !ok {
+		i, ok = index[path.Base(name)] // Tap formulae (oven-sh/bun/bun) installed from another tap name
+	}
+	if !ok {
+		return BrewPackage{}, false
+	}
+	return packages[i], true
+}
+
+// Outdated returns the formulae then the casks brew would upgrade
+func (inv *BrewInventory) Outdated() []BrewPackage {
+	var outdated []BrewPackage
+	for _, p := range append(append([]BrewPackage{}, inv.Formulae...), inv.Casks...) {
+		if p.Outdated {
+			outdated = append(outdated, p)
+		}
+	}
+	return outdated
+}
+
+// brewInventory is loaded by the first lookup, then shared by every check
+var brewInventory struct {
+	mu     sync.Mutex
+	loaded bool
+	inv    *BrewInventory
+	err    error
+}
+
+// Brew returns the brew inventory, running `brew info` on first use only
+func Brew() (*BrewInventory, error) {
+	brewInventory.mu.Lock()
+	defer brewInventory.mu.Unlock()
+	if !brewInventory.loaded {
+		brewInventory.inv, brewInventory.err = loadBrewInventory()
+		brewInventory.loaded = true
+	}
+	return brewInventory.inv, brewInventory.err
+}
+
+func loadBrewInventory() (*BrewInventory, error) {
+	out, err := Output("brew", "info", "--json=v2", "--installed")
+	if err != nil {
+		return nil, fmt.Errorf("brew info failed: %w", err)
+	}
+	return ParseBrewInventory(out)
+}
+
+// ResetBrewInventory drops the inventory, so the next lookup asks brew again
+// (after installs, or when the runner changes)
+func ResetBrewInventory() {
+	brewInventory.mu.Lock()
+	defer brewInventory.mu.Unlock()
+	brewInventory.loaded, brewInventory.inv, brewInventory.err = false, nil, nil
+}
@@ -0,0 +1,109 @@
+package tool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseBrewInventory(t *testing.T) {
+	inv, err := ParseBrewInventory(readFixture(t, "brew-info-installed.json"))
+	if err != nil {
+		t.Fatalf("ParseBrewInventory() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		given    string
+		cask     bool
+		expected BrewPackage
+	}{
+		{"formula", "go", false, BrewPackage{Name: "go", Version: "1.24.4", Latest: "1.24.5", Outdated: true}},
+		{"alias", "golang", false, BrewPackage{Name: "go", Version: "1.24.4", Latest: "1.24.5", Outdated: true}},
+		{"tap formula", "oven-sh/bun/bun", false, BrewPackage{Name: "bun", Version: "1.2.19", Latest: "1.2.19"}},
+		{"unlinked kegs use the newest", "node", false, BrewPackage{Name: "node", Version: "24.4.1_1", Latest: "24.4.1_1"}},
+		{"versioned formula alias", "python3", false, BrewPackage{Name: "python@3.13", Version: "3.13.5", Latest: "3.13.5"}},
+		{"cask", "ghostty", true, BrewPackage{Name: "ghostty", Version: "1.1.2", Latest: "1.1.3", Outdated: true, Cask: true}},
+		{"not installed", "jq", false, BrewPackage{}},
+		{"formula is not a cask", "go", true, BrewPackage{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := inv.Formula
+			if tt.cask {
+				lookup = inv.Cask
+			}
+			got, ok := lookup(tt.given)
+			if ok != (tt.expected.Name != "") || got != tt.expected {
+				t.Errorf("lookup(%q) = %+v, %v, want %+v", tt.given, got, ok, tt.expected)
+			}
+		})
+	}
+
+	var outdated []string
+	for _, p := range inv.Outdated() {
+		outdated = append(outdated, p.Name)
+	}
+	if expected := []string{"go", "ghostty"}; !reflect.DeepEqual(outdated, expected) {
+		t.Errorf("Outdated() = %v, want %v", outdated, expected)
+	}
+
+	empty, err := ParseBrewInventory(readFixture(t, "brew-info-empty.json"))
+	if err != nil || len(empty.Formulae)+len(empty.Casks) != 0 || empty.Outdated() != nil {
+		t.Errorf("ParseBrewInventory(empty) = %+v, %v, want nothing installed", empty, err)
+	}
+	if _, err := ParseBrewInventory([]byte("Error: not json")); err == nil {
+		t.Error("ParseBrewInventory(garbage) should fail")
+	}
+}
+
+func TestBrewInventoryLoadsOnce(t *testing.T) {
+	fake := NewFakeRunner().
+		WithPath("brew").
+		On("brew info --json=v2 --installed", FakeResponse{Stdout: string(readFixture(t, "brew-info-installed.json"))})
+	t.Cleanup(SetRunner(fake))
+
+	if got := VersionFromBrewFormula("go")(); got != "1.24.4" {
+		t.Errorf("VersionFromBrewFormula(go) = %q, want 1.24.4", got)
+	}
+	if got := VersionFromBrewCask("zed")(); got != "0.195.5" {
+		t.Errorf("VersionFromBrewCask(zed) = %q, want 0.195.5", got)
+	}
+	if got := VersionFromBrewFormula("jq")(); got != "" {
+		t.Errorf("VersionFromBrewFormula(jq) = %q, want empty", got)
+	}
+	if calls := len(fake.Commands()); calls != 1 {
+		t.Errorf("brew ran %d times, want 1: %v", calls, fake.Commands())
+	}
+
+	ResetBrewInventory()
+	VersionFromBrewFormula("go")()
+	if calls := len(fake.Commands()); calls != 2 {
+		t.Errorf("brew ran %d times after a reset, want 2", calls)
+	}
+}
+
+func TestBrewInventoryFails(t *testing.T) {
+	fake := NewFakeRunner().
+		On("brew info --json=v2 --installed", FakeResponse{Err: errors.New("executable file not found")})
+	t.Cleanup(SetRunner(fake))
+
+	if _, err := Brew(); err == nil {
+		t.Error("Brew() should fail when brew does")
+	}
+	if got := VersionFromBrewCask("zed")(); got != "" {
+		t.Errorf("VersionFromBrewCask(zed) = %q, want empty", got)
+	}
+}
@@ -192,6 +192,103 @@ func ParseEasVersion(s string) string {
 	return ""
 }
 
+// ParseUvVersion parses "uv 0.4.20 (0e1b25a53 2024-10-08)" -> "0.4.20"
+func ParseUvVersion(s string) string {
+	return parseFirstLineField(s, 1, false)
+}
+
+// ParseMasListVersion finds an app by id in `mas list` output
+// ("497799835  Xcode  (16.2)") and returns its version ("16.2")
+func ParseMasListVersion(s string, id int) (string, bool) {
+	want := fmt.Sprint(id)
+	for _, line := range strings.Split(StripAnsi(s), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != want {
+			continue
+		}
+		last := fields[len(fields)-1]
+		if strings.HasPrefix(last, "(") && strings.HasSuffix(last, ")") {
+			return strings.Trim(last, "()"), true
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// ParseCargoInstallList reads `cargo install --list` and returns the version
+// of each crate installed from crates.io. Crates installed from git or a
+// local path ("foo v0.1.0 (/src/foo):") are left out.
+//
+//	ripgrep v14.1.0:
+//	    rg
+func ParseCargoInstallList(s string) map[string]string {
+	crates := make(map[string]string)
+	for _, line := range strings.Split(StripAnsi(s), "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		fields := strings.Fields(strings.TrimSuffix(line, ":"))
+		if len(fields) != 2 {
+			continue
+		}
+		crates[fields[0]] = strings.TrimPrefix(fields[1], "v")
+	}
+	return crates
+}
+
+// ParseUvToolList reads `uv tool list` and returns the version of each tool
+//
+//	ruff v0.6.9
+//	- ruff
+func ParseUvToolList(s string) map[string]string {
+	tools := make(map[string]string)
+	for _, line := range strings.Split(StripAnsi(s), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == "-" || !strings.HasPrefix(fields[1], "v") {
+			continue
+		}
+		tools[fields[0]] = strings.TrimPrefix(fields[1], "v")
+	}
+	return tools
+}
+
+// ParsePipxList reads `pipx list --short` ("ruff 0.6.9") and returns the
+// version of each package
+func ParsePipxList(s string) map[string]string {
+	packages := make(map[string]string)
+	for _, line := range strings.Split(StripAnsi(s), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		packages[fields[0]] = fields[1]
+	}
+	return packages
+}
+
+// ParseGoVersionM reads `go version -m <binary or dir>` and returns the module
+// version of each main package. Local builds report "(devel)" and are left out.
+//
+//	/Users/me/go/bin/gopls: go1.24.0
+//		path	golang.org/x/tools/gopls
+//		mod	golang.org/x/tools/gopls	v0.16.2	h1:...
+func ParseGoVersionM(s string) map[string]string {
+	packages := make(map[string]string)
+	path := ""
+	for _, line := range strings.Split(StripAnsi(s), "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) >= 2 && fields[0] == "path":
+			path = fields[1]
+		case len(fields) >= 3 && fields[0] == "mod" && path != "":
+			if fields[2] != "(devel)" {
+				packages[path] = strings.TrimPrefix(fields[2], "v")
+			}
+			path = ""
+		}
+	}
+	return packages
+}
 
 // =============================================================================
 // Formatters
@@ -230,3 +327,25 @@ func FilterStrings(slice, exclude []string) []string {
 	}
 	return result
 }
+
+// EditDistance returns the Levenshtein distance between a and b
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -1,6 +1,9 @@
 package tool
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestVersionParsers(t *testing.T) {
 	tests := []struct {
@@ -132,3 +135,97 @@ func TestFormatBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMasListVersion(t *testing.T) {
+	list := "409201541   Pages                 (14.3)\n497799835   Xcode                 (16.2)\n1153157709  Speedtest\n"
+	tests := []struct {
+		name          string
+		given         int
+		expected      string
+		expectedFound bool
+	}{
+		{"with version", 497799835, "16.2", true},
+		{"without version", 1153157709, "", true},
+		{"not installed", 424389933, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, found := ParseMasListVersion(list, tt.given)
+			if version != tt.expected || found != tt.expectedFound {
+				t.Errorf("ParseMasListVersion(%d) = %q, %v, want %q, %v", tt.given, version, found, tt.expected, tt.expectedFound)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"go", "", 2},
+		{"homebrew", "homebrew", 0},
+		{"jav", "java", 1},
+		{"pyhton", "python", 2},
+		{"orbstak", "orbstack", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("EditDistance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestParsePackageLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func(string) map[string]string
+		given    string
+		expected map[string]string
+	}{
+		{
+			name:     "cargo install --list",
+			parse:    ParseCargoInstallList,
+			given:    "cargo-nextest v0.9.72:\n    cargo-nextest\nripgrep v14.1.0:\n    rg\nlocal v0.1.0 (/src/local):\n    local\n",
+			expected: map[string]string{"cargo-nextest": "0.9.72", "ripgrep": "14.1.0"},
+		},
+		{
+			name:     "uv tool list",
+			parse:    ParseUvToolList,
+			given:    "ruff v0.6.9\n- ruff\nhttpie v3.2.3\n- http\n- https\n",
+			expected: map[string]string{"ruff": "0.6.9", "httpie": "3.2.3"},
+		},
+		{
+			name:     "pipx list --short",
+			parse:    ParsePipxList,
+			given:    "black 24.8.0\npoetry 1.8.3\n",
+			expected: map[string]string{"black": "24.8.0", "poetry": "1.8.3"},
+		},
+		{
+			name:  "go version -m",
+			parse: ParseGoVersionM,
+			given: "/home/me/go/bin/gopls: go1.24.0\n\tpath\tgolang.org/x/tools/gopls\n\tmod\tgolang.org/x/tools/gopls\tv0.16.2\th1:abc=\n\tdep\tgolang.org/x/mod\tv0.20.0\th1:def=\n" +
+				"/home/me/go/bin/staticcheck: go1.24.0\n\tpath\thonnef.co/go/tools/cmd/staticcheck\n\tmod\thonnef.co/go/tools\tv0.5.1\th1:ghi=\n" +
+				"/home/me/go/bin/j: go1.24.0\n\tpath\tgithub.com/jterrazz/jterrazz-cli/src/cmd/j\n\tmod\tgithub.com/jterrazz/jterrazz-cli\t(devel)\t\n",
+			expected: map[string]string{"golang.org/x/tools/gopls": "0.16.2", "honnef.co/go/tools/cmd/staticcheck": "0.5.1"},
+		},
+		{
+			name:     "empty",
+			parse:    ParseUvToolList,
+			given:    "No tools installed\n",
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.parse(tt.given); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package tool
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// =============================================================================
+// PATH - Inspect where commands resolve
+// =============================================================================
+
+// LookPathAll returns every executable named file in the directories of
+// pathEnv, in PATH order (the first one is the one that runs). Copies
+// resolving to the same file, e.g. through a symlinked directory, are kept once.
+func LookPathAll(file, pathEnv string) []string {
+	var found []string
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		candidate := filepath.Join(dir, file)
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+		resolved, err := filepath.EvalSymlinks(candidate)
+		if err != nil {
+			resolved = candidate
+		}
+		if seen[resolved] {
+			continue
+		}
+		seen[resolved] = true
+		found = append(found, candidate)
+	}
+	return found
+}
+
+// PathDuplicates returns the directories listed more than once in pathEnv
+func PathDuplicates(pathEnv string) []string {
+	var duplicates []string
+	counts := make(map[string]int)
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		counts[dir]++
+		if counts[dir] == 2 {
+			duplicates = append(duplicates, dir)
+		}
+	}
+	return duplicates
+}
@@ -0,0 +1,52 @@
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookPathAll(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"brew", "system", "empty"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"brew/node", "system/node", "system/python3"} {
+		if err := os.WriteFile(filepath.Join(root, file), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "empty/node"), []byte("not executable"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "brew"), filepath.Join(root, "linked")); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := func(name string) string { return filepath.Join(root, name) }
+	path := strings.Join([]string{dir("empty"), dir("brew"), dir("linked"), "", dir("system"), dir("brew")}, string(os.PathListSeparator))
+
+	tests := []struct {
+		given    string
+		expected []string
+	}{
+		{"node", []string{dir("brew/node"), dir("system/node")}},
+		{"python3", []string{dir("system/python3")}},
+		{"go", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			if got := LookPathAll(tt.given, path); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("LookPathAll(%q) = %v, expected %v", tt.given, got, tt.expected)
+			}
+		})
+	}
+
+	if got, expected := PathDuplicates(path), []string{dir("brew")}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("PathDuplicates() = %v, expected %v", got, expected)
+	}
+}
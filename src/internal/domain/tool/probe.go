@@ -0,0 +1,56 @@
+package tool
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// =============================================================================
+// Probes - Ask running services whether they answer
+// =============================================================================
+
+// ProbeTimeout bounds every probe, so a hung daemon cannot stall j status
+var ProbeTimeout = 2 * time.Second
+
+// maxProbeBody caps how much of a probe response is read
+const maxProbeBody = 1 << 20
+
+// HTTPGet fetches url and returns the status code and body
+func HTTPGet(url string) (int, []byte, error) {
+	return probe(&http.Client{Timeout: ProbeTimeout}, url, "")
+}
+
+// UnixHTTPGet fetches path from an HTTP server listening on a unix socket
+// (the Docker API, the tailscaled LocalAPI). host is sent as the Host header.
+func UnixHTTPGet(socket, host, path string) (int, []byte, error) {
+	client := &http.Client{
+		Timeout: ProbeTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	return probe(client, "http://"+host+path, host)
+}
+
+func probe(client *http.Client, url, host string) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	if host != "" {
+		req.Host = host
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBody))
+	return resp.StatusCode, body, err
+}
@@ -0,0 +1,415 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// =============================================================================
+// Runner - Single entry point for spawning external commands
+// =============================================================================
+
+// Cmd describes an external command invocation
+type Cmd struct {
+	Name    string
+	Args    []string
+	Dir     string        // Working directory (empty = current)
+	Env     []string      // Extra KEY=value pairs appended to the environment
+	Stdin   io.Reader     // nil = no input
+	Stdout  io.Writer     // nil = discarded
+	Stderr  io.Writer     // nil = discarded
+	Timeout time.Duration // 0 = no timeout
+	Logged  bool          // Changes the system (install, upgrade, uninstall): kept in the run log
+
+	// Interactive commands (sudo, prompting scripts) keep the terminal: their
+	// terminal output is not copied into the run log
+	Interactive bool
+}
+
+// String returns the command line, e.g. "brew install go"
+func (c Cmd) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Runner executes external commands. Swap it with SetRunner to fake the system in tests.
+type Runner interface {
+	// Run runs the command to completion
+	Run(c Cmd) error
+	// Start launches the command detached in its own session and returns its pid
+	Start(c Cmd) (int, error)
+	// LookPath resolves a command in PATH
+	LookPath(file string) (string, error)
+}
+
+var (
+	runnerMu sync.RWMutex
+	runner   Runner = ExecRunner{}
+)
+
+// CurrentRunner returns the runner used by all command helpers
+func CurrentRunner() Runner {
+	runnerMu.RLock()
+	defer runnerMu.RUnlock()
+	return runner
+}
+
+// SetRunner replaces the runner and returns a func restoring the previous one
+func SetRunner(r Runner) (restore func()) {
+	runnerMu.Lock()
+	previous := runner
+	runner = r
+	runnerMu.Unlock()
+	ResetBrewInventory() // Read from the old system
+	return func() { SetRunner(previous) }
+}
+
+// Run runs a command with stdin/stdout/stderr attached to the terminal
+func Run(name string, args ...string) error {
+	return CurrentRunner().Run(Cmd{Name: name, Args: args, Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr})
+}
+
+// RunLogged runs a command changing the system with the terminal attached,
+// recording it and its output in the run log of the current command (see
+// LoggingRunner). sudo commands are interactive: they may ask for a password.
+func RunLogged(name string, args ...string) error {
+	return CurrentRunner().Run(Cmd{Name: name, Args: args, Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr, Logged: true, Interactive: name == "sudo"})
+}
+
+// RunLoggedInteractive runs a prompting command changing the system with the
+// terminal attached. The run log records the command and its exit status only.
+func RunLoggedInteractive(name string, args ...string) error {
+	return CurrentRunner().Run(Cmd{Name: name, Args: args, Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr, Logged: true, Interactive: true})
+}
+
+// RunSilent runs a command discarding its output, for exit-status checks
+func RunSilent(name string, args ...string) error {
+	return CurrentRunner().Run(Cmd{Name: name, Args: args})
+}
+
+// RunCmd runs a fully described command
+func RunCmd(c Cmd) error {
+	return CurrentRunner().Run(c)
+}
+
+// Start launches a detached background command and returns its pid
+func Start(c Cmd) (int, error) {
+	return CurrentRunner().Start(c)
+}
+
+// Output runs a command and returns its stdout
+func Output(name string, args ...string) ([]byte, error) {
+	var stdout bytes.Buffer
+	err := CurrentRunner().Run(Cmd{Name: name, Args: args, Stdout: &stdout})
+	return stdout.Bytes(), err
+}
+
+// CombinedOutput runs a command and returns its stdout and stderr interleaved
+func CombinedOutput(name string, args ...string) ([]byte, error) {
+	var out bytes.Buffer
+	err := CurrentRunner().Run(Cmd{Name: name, Args: args, Stdout: &out, Stderr: &out})
+	return out.Bytes(), err
+}
+
+// LookPath resolves a command in PATH
+func LookPath(file string) (string, error) {
+	return CurrentRunner().LookPath(file)
+}
+
+// =============================================================================
+// ExecRunner - Real implementation backed by os/exec
+// =============================================================================
+
+// ExecRunner runs commands on the host with os/exec
+type ExecRunner struct{}
+
+// Run implements Runner
+func (ExecRunner) Run(c Cmd) error {
+	ctx := context.Background()
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+
+	err := cmd.Run()
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("command timed out after %v", c.Timeout)
+	}
+	return err
+}
+
+// Start implements Runner
+func (ExecRunner) Start(c Cmd) (int, error) {
+	cmd := exec.Command(c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+	_ = cmd.Process.Release()
+	return pid, nil
+}
+
+// LookPath implements Runner
+func (ExecRunner) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+// =============================================================================
+// LoggingRunner - Tees every command into a run log
+// =============================================================================
+
+// LoggingRunner wraps a runner and appends each Logged command, its output and
+// its exit status to a log. Other commands (checks and probes) run untouched.
+// Output is buffered per command so concurrent commands do not interleave in
+// the log; Interactive commands writing to the terminal keep their TTY, so
+// only their captured output is logged.
+type LoggingRunner struct {
+	runner Runner
+
+	mu       sync.Mutex
+	log      io.Writer
+	commands int
+	failed   int
+}
+
+// NewLoggingRunner returns a runner running commands with r and logging them to log
+func NewLoggingRunner(r Runner, log io.Writer) *LoggingRunner {
+	return &LoggingRunner{runner: r, log: log}
+}
+
+// Run implements Runner
+func (l *LoggingRunner) Run(c Cmd) error {
+	if !c.Logged {
+		return l.runner.Run(c)
+	}
+
+	var output lockedBuffer
+	logged := c
+	logged.Stdout = teeWriter(c.Stdout, &output, c.Interactive)
+	logged.Stderr = teeWriter(c.Stderr, &output, c.Interactive)
+
+	start := time.Now()
+	err := l.runner.Run(logged)
+	captured := output.String()
+	if c.Interactive && (isFile(c.Stdout) || isFile(c.Stderr)) {
+		captured += "(interactive, output shown on the terminal)\n"
+	}
+	l.write(c, start, captured, err)
+	return err
+}
+
+// Start implements Runner
+func (l *LoggingRunner) Start(c Cmd) (int, error) {
+	pid, err := l.runner.Start(c)
+	if c.Logged {
+		l.write(c, time.Now(), fmt.Sprintf("started in the background (pid %d)\n", pid), err)
+	}
+	return pid, err
+}
+
+// LookPath implements Runner
+func (l *LoggingRunner) LookPath(file string) (string, error) {
+	return l.runner.LookPath(file)
+}
+
+// Counts returns the number of logged commands and how many of them failed
+func (l *LoggingRunner) Counts() (commands, failed int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.commands, l.failed
+}
+
+func (l *LoggingRunner) write(c Cmd, start time.Time, output string, err error) {
+	status := "ok"
+	if err != nil {
+		status = "failed: " + err.Error()
+	}
+	if output != "" && !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.commands++
+	if err != nil {
+		l.failed++
+	}
+	fmt.Fprintf(l.log, "[%s] $ %s\n%s[%s in %s]\n\n",
+		start.Format("15:04:05"), c.String(), output, status, time.Since(start).Round(time.Millisecond))
+}
+
+// teeWriter copies writes to w (when set) and to log. The files of interactive
+// commands are returned as is: wrapping the terminal would hide the TTY.
+func teeWriter(w io.Writer, log io.Writer, interactive bool) io.Writer {
+	if w == nil {
+		return log
+	}
+	if interactive && isFile(w) {
+		return w
+	}
+	return io.MultiWriter(w, log)
+}
+
+// isFile reports whether w is an *os.File (usually the terminal), which os/exec
+// hands to the command directly
+func isFile(w io.Writer) bool {
+	_, ok := w.(*os.File)
+	return ok
+}
+
+// lockedBuffer is a bytes.Buffer safe for the concurrent stdout and stderr copies of os/exec
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// =============================================================================
+// FakeRunner - Recording implementation for tests
+// =============================================================================
+
+// FakeResponse is the canned result of a faked command
+type FakeResponse struct {
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+// FakeRunner records every command and answers from canned responses.
+// Commands without a response succeed with no output.
+type FakeRunner struct {
+	mu        sync.Mutex
+	calls     []Cmd
+	responses map[string]FakeResponse
+	paths     map[string]string
+}
+
+// NewFakeRunner creates an empty fake runner
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{
+		responses: make(map[string]FakeResponse),
+		paths:     make(map[string]string),
+	}
+}
+
+// On registers the response for an exact command line (e.g. "brew --version")
+func (f *FakeRunner) On(cmdline string, resp FakeResponse) *FakeRunner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[cmdline] = resp
+	return f
+}
+
+// WithPath makes LookPath resolve the given commands to /usr/local/bin/<name>
+func (f *FakeRunner) WithPath(names ...string) *FakeRunner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, name := range names {
+		f.paths[name] = "/usr/local/bin/" + name
+	}
+	return f
+}
+
+// Run implements Runner
+func (f *FakeRunner) Run(c Cmd) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, c)
+	resp := f.responses[c.String()]
+	f.mu.Unlock()
+
+	if c.Stdout != nil && resp.Stdout != "" {
+		io.WriteString(c.Stdout, resp.Stdout)
+	}
+	if c.Stderr != nil && resp.Stderr != "" {
+		io.WriteString(c.Stderr, resp.Stderr)
+	}
+	return resp.Err
+}
+
+// Start implements Runner
+func (f *FakeRunner) Start(c Cmd) (int, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, c)
+	resp := f.responses[c.String()]
+	f.mu.Unlock()
+	if resp.Err != nil {
+		return 0, resp.Err
+	}
+	return 4242, nil
+}
+
+// LookPath implements Runner
+func (f *FakeRunner) LookPath(file string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if path, ok := f.paths[file]; ok {
+		return path, nil
+	}
+	return "", &exec.Error{Name: file, Err: exec.ErrNotFound}
+}
+
+// Calls returns the recorded invocations
+func (f *FakeRunner) Calls() []Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]Cmd, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// Commands returns the recorded command lines
+func (f *FakeRunner) Commands() []string {
+	calls := f.Calls()
+	lines := make([]string, len(calls))
+	for i, c := range calls {
+		lines[i] = c.String()
+	}
+	return lines
+}
+
+// Record runs fn against a FakeRunner and returns the command lines it would
+// have executed. Nothing touches the system as long as fn only spawns commands.
+func Record(fn func() error) []string {
+	fake := NewFakeRunner()
+	restore := SetRunner(fake)
+	defer restore()
+	_ = fn()
+	return fake.Commands()
+}
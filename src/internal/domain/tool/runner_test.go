@@ -0,0 +1,139 @@
+package tool
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFakeRunner(t *testing.T) {
+	fake := NewFakeRunner().
+		WithPath("brew").
+		On("brew --version", FakeResponse{Stdout: "Homebrew 4.2.0\n"}).
+		On("brew info nope", FakeResponse{Stderr: "No available formula", Err: errors.New("exit status 1")})
+	t.Cleanup(SetRunner(fake))
+
+	out, err := Output("brew", "--version")
+	if err != nil || ParseBrewVersion(string(out)) != "4.2.0" {
+		t.Fatalf("Output() = %q, %v", out, err)
+	}
+
+	combined, err := CombinedOutput("brew", "info", "nope")
+	if err == nil || string(combined) != "No available formula" {
+		t.Fatalf("CombinedOutput() = %q, %v", combined, err)
+	}
+
+	if err := RunSilent("git", "status"); err != nil {
+		t.Fatalf("unregistered commands should succeed, got %v", err)
+	}
+
+	if !CommandExists("brew") || CommandExists("bun") {
+		t.Error("LookPath should only resolve registered commands")
+	}
+
+	expected := []string{"brew --version", "brew info nope", "git status"}
+	got := fake.Commands()
+	if len(got) != len(expected) {
+		t.Fatalf("Commands() = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Commands()[%d] = %q, want %q", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestSetRunnerRestore(t *testing.T) {
+	original := CurrentRunner()
+	restore := SetRunner(NewFakeRunner())
+	if _, ok := CurrentRunner().(*FakeRunner); !ok {
+		t.Fatal("SetRunner() did not install the fake")
+	}
+	restore()
+	if CurrentRunner() != original {
+		t.Fatal("restore() did not reinstall the previous runner")
+	}
+}
+
+func TestLoggingRunner(t *testing.T) {
+	fake := NewFakeRunner().
+		On("brew install go", FakeResponse{Stdout: "==> Pouring go\n"}).
+		On("brew install nope", FakeResponse{Stderr: "No available formula", Err: errors.New("exit status 1")})
+	var log bytes.Buffer
+	logging := NewLoggingRunner(fake, &log)
+	t.Cleanup(SetRunner(logging))
+
+	var captured bytes.Buffer
+	if err := RunCmd(Cmd{Name: "brew", Args: []string{"install", "go"}, Stdout: &captured, Logged: true}); err != nil {
+		t.Fatalf("RunCmd() error = %v", err)
+	}
+	if captured.String() != "==> Pouring go\n" {
+		t.Errorf("the caller should still get the output, got %q", captured.String())
+	}
+	if err := RunCmd(Cmd{Name: "brew", Args: []string{"install", "nope"}, Logged: true}); err == nil {
+		t.Fatal("RunCmd() should return the command error")
+	}
+	if err := RunSilent("brew", "info", "nope"); err != nil {
+		t.Fatalf("RunSilent() error = %v", err)
+	}
+	if err := RunCmd(Cmd{Name: "sudo", Args: []string{"apt-get", "install", "jq"}, Stdout: os.Stdout, Logged: true, Interactive: true}); err != nil {
+		t.Fatalf("RunCmd() error = %v", err)
+	}
+	if c := fake.Calls()[len(fake.Calls())-1]; c.Stdout != os.Stdout {
+		t.Error("the terminal should reach interactive commands unwrapped")
+	}
+
+	for _, want := range []string{
+		"$ brew install go\n==> Pouring go\n[ok in",
+		"$ brew install nope\nNo available formula\n[failed: exit status 1 in",
+		"$ sudo apt-get install jq\n(interactive, output shown on the terminal)\n[ok in",
+	} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("log = %q, want containing %q", log.String(), want)
+		}
+	}
+	if strings.Contains(log.String(), "brew info") {
+		t.Errorf("log = %q, commands without Logged should not be logged", log.String())
+	}
+	if commands, failed := logging.Counts(); commands != 3 || failed != 1 {
+		t.Errorf("Counts() = %d, %d, want 3, 1", commands, failed)
+	}
+}
+
+func TestLoggingRunnerTeesFiles(t *testing.T) {
+	fake := NewFakeRunner().
+		On("brew install nope", FakeResponse{Stdout: "==> Fetching nope\n", Stderr: "Error: No available formula with the name \"nope\"", Err: errors.New("exit status 1")})
+	logPath := filepath.Join(t.TempDir(), "run.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(SetRunner(NewLoggingRunner(fake, logFile)))
+
+	// A file standing in for the terminal a non-interactive install writes to
+	terminal, err := os.Create(filepath.Join(t.TempDir(), "terminal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer terminal.Close()
+	if err := RunCmd(Cmd{Name: "brew", Args: []string{"install", "nope"}, Stdout: terminal, Stderr: terminal, Logged: true}); err == nil {
+		t.Fatal("RunCmd() should return the command error")
+	}
+	logFile.Close()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"==> Fetching nope", `Error: No available formula with the name "nope"`, "[failed: exit status 1"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("log = %q, want containing %q", data, want)
+		}
+	}
+	if shown, _ := os.ReadFile(terminal.Name()); !strings.Contains(string(shown), "No available formula") {
+		t.Errorf("terminal = %q, the output should still be shown", shown)
+	}
+}
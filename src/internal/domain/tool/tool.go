@@ -1,9 +1,7 @@
 package tool
 
 import (
-	"context"
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 )
@@ -15,7 +13,7 @@ import (
 // VersionFromCmd creates a version func that runs a command and parses output
 func VersionFromCmd(cmd string, args []string, parser func(string) string) func() string {
 	return func() string {
-		out, err := exec.Command(cmd, args...).CombinedOutput()
+		out, err := CombinedOutput(cmd, args...)
 		if err != nil {
 			return ""
 		}
@@ -23,35 +21,27 @@ func VersionFromCmd(cmd string, args []string, parser func(string) string) func(
 	}
 }
 
-// VersionFromBrewFormula creates a version func that gets version from brew info
+// VersionFromBrewFormula creates a version func that gets version from the brew inventory
 func VersionFromBrewFormula(formula string) func() string {
 	return func() string {
-		out, err := exec.Command("brew", "list", "--versions", formula).Output()
+		inv, err := Brew()
 		if err != nil {
 			return ""
 		}
-		// Output: "formula 1.2.3" or "formula 1.2.3 1.2.2"
-		parts := strings.Fields(string(out))
-		if len(parts) >= 2 {
-			return parts[1]
-		}
-		return ""
+		pkg, _ := inv.Formula(formula)
+		return pkg.Version
 	}
 }
 
-// VersionFromBrewCask creates a version func that gets version from brew cask info
+// VersionFromBrewCask creates a version func that gets version from the brew inventory
 func VersionFromBrewCask(cask string) func() string {
 	return func() string {
-		out, err := exec.Command("brew", "list", "--cask", "--versions", cask).Output()
+		inv, err := Brew()
 		if err != nil {
 			return ""
 		}
-		// Output: "cask 1.2.3"
-		parts := strings.Fields(string(out))
-		if len(parts) >= 2 {
-			return parts[1]
-		}
-		return ""
+		pkg, _ := inv.Cask(cask)
+		return pkg.Version
 	}
 }
 
@@ -59,7 +49,7 @@ func VersionFromBrewCask(cask string) func() string {
 func VersionFromAppPlist(appName string) func() string {
 	return func() string {
 		plistPath := fmt.Sprintf("/Applications/%s.app/Contents/Info.plist", appName)
-		out, err := exec.Command("defaults", "read", plistPath, "CFBundleShortVersionString").Output()
+		out, err := Output("defaults", "read", plistPath, "CFBundleShortVersionString")
 		if err != nil {
 			return ""
 		}
@@ -69,13 +59,13 @@ func VersionFromAppPlist(appName string) func() string {
 
 // CommandExists checks if a command exists in PATH
 func CommandExists(cmd string) bool {
-	_, err := exec.LookPath(cmd)
+	_, err := LookPath(cmd)
 	return err == nil
 }
 
 // GetCommandOutput runs a command and returns its trimmed output, or empty string on error
 func GetCommandOutput(name string, args ...string) string {
-	out, err := exec.Command(name, args...).Output()
+	out, err := Output(name, args...)
 	if err != nil {
 		return ""
 	}
@@ -84,16 +74,9 @@ func GetCommandOutput(name string, args ...string) string {
 
 // GetCommandOutputWithTimeout runs a command with a timeout and returns its trimmed output
 func GetCommandOutputWithTimeout(timeout time.Duration, name string, args ...string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, name, args...)
-	out, err := cmd.Output()
-	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("command timed out after %v", timeout)
-		}
+	var stdout strings.Builder
+	if err := RunCmd(Cmd{Name: name, Args: args, Stdout: &stdout, Timeout: timeout}); err != nil {
 		return "", err
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(stdout.String()), nil
 }
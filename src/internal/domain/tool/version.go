@@ -0,0 +1,267 @@
+package tool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// Version - Parse, compare and constrain tool versions
+// =============================================================================
+
+// Version is a semantic version parsed leniently from tool output
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string // Pre-release ("rc1" in 1.2.0-rc1), sorts before the release
+	Post                string // Letter revision ("a" in tmux 3.6a), sorts after the release
+
+	parts int // Numeric components given: "1.2" has 2, so it matches 1.2.x in constraints
+}
+
+// ParseVersion parses versions as the Parse*Version helpers return them and
+// the raw strings they come from: "21.0.2", "go1.24", "v3.100.0",
+// "eas-cli/16.32.0", "codex 0.1.0", "1.12.0+mac", "3.6a", "1.8.0_392".
+// Anything before the first digit is ignored, as is build metadata.
+func ParseVersion(s string) (Version, error) {
+	raw := s
+	s = StripAnsi(strings.TrimSpace(s))
+	start := strings.IndexFunc(s, unicode.IsDigit)
+	if start < 0 {
+		return Version{}, fmt.Errorf("invalid version %q", raw)
+	}
+	s = s[start:]
+	if end := strings.IndexFunc(s, unicode.IsSpace); end >= 0 {
+		s = s[:end]
+	}
+
+	var v Version
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for v.parts < len(numbers) {
+		end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			break
+		}
+		n, err := strconv.Atoi(s[:end])
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", raw, err)
+		}
+		*numbers[v.parts] = n
+		v.parts++
+		s = s[end:]
+		if v.parts == len(numbers) || !strings.HasPrefix(s, ".") || len(s) < 2 || !unicode.IsDigit(rune(s[1])) {
+			break
+		}
+		s = s[1:]
+	}
+
+	// Drop build metadata (+mac, _392) and extra components (1.2.3.4)
+	if end := strings.IndexAny(s, "+_"); end >= 0 {
+		s = s[:end]
+	}
+	for strings.HasPrefix(s, ".") && len(s) > 1 && unicode.IsDigit(rune(s[1])) {
+		s = strings.TrimLeft(s[1:], "0123456789")
+	}
+
+	switch {
+	case s == "":
+	case strings.HasPrefix(s, "-"):
+		v.Pre = strings.TrimLeft(s[1:], ".")
+	case len(s) == 1 && unicode.IsLetter(rune(s[0])):
+		v.Post = s
+	default:
+		v.Pre = strings.TrimLeft(s, ".")
+	}
+	return v, nil
+}
+
+// String formats the version as major.minor.patch, with its suffix
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Post != "" {
+		s += v.Post
+	}
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 when v is older than, equal to or newer than o
+func (v Version) Compare(o Version) int {
+	for _, pair := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if pair[0] != pair[1] {
+			return compareInts(pair[0], pair[1])
+		}
+	}
+	if c := compareSuffix(v.Post, o.Post); c != 0 {
+		return c
+	}
+	// A release is newer than its pre-releases
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+	return compareSuffix(v.Pre, o.Pre)
+}
+
+// Less reports whether v is older than o
+func (v Version) Less(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareSuffix orders suffixes like "rc2" < "rc10" by their trailing number
+func compareSuffix(a, b string) int {
+	aText, aNum := splitTrailingNumber(a)
+	bText, bNum := splitTrailingNumber(b)
+	if aText != bText {
+		return strings.Compare(aText, bText)
+	}
+	return compareInts(aNum, bNum)
+}
+
+func splitTrailingNumber(s string) (string, int) {
+	i := len(s)
+	for i > 0 && unicode.IsDigit(rune(s[i-1])) {
+		i--
+	}
+	n, _ := strconv.Atoi(s[i:])
+	return s[:i], n
+}
+
+// =============================================================================
+// Constraints
+// =============================================================================
+
+// Constraint is a set of version comparisons that must all hold, e.g. ">=1.2 <2"
+type Constraint struct {
+	raw   string
+	terms []constraintTerm
+}
+
+type constraintTerm struct {
+	op      string // "=", "!=", ">", ">=", "<", "<="
+	version Version
+}
+
+// constraintOps are matched longest first
+var constraintOps = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// ParseConstraint parses comparisons separated by spaces or commas. A bare
+// version means "=", and partial versions match every patch: "=1.2" matches
+// 1.2.7 and "<2" excludes 2.0.1.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: strings.TrimSpace(s)}
+	fields := strings.FieldsFunc(s, func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		op := "="
+		for _, candidate := range constraintOps {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				field = field[len(candidate):]
+				break
+			}
+		}
+		if op == "==" {
+			op = "="
+		}
+		// Allow a space after the operator: ">= 1.2"
+		if field == "" && i+1 < len(fields) {
+			i++
+			field = fields[i]
+		}
+
+		field = strings.TrimPrefix(field, "v")
+		if field == "" || !unicode.IsDigit(rune(field[0])) {
+			return Constraint{}, fmt.Errorf("invalid version constraint %q", s)
+		}
+		v, err := ParseVersion(field)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+		c.terms = append(c.terms, constraintTerm{op: op, version: v})
+	}
+	if len(c.terms) == 0 {
+		return Constraint{}, fmt.Errorf("empty version constraint")
+	}
+	return c, nil
+}
+
+// Check reports whether v satisfies every comparison of the constraint
+func (c Constraint) Check(v Version) bool {
+	for _, term := range c.terms {
+		if !term.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the constraint as written
+func (c Constraint) String() string {
+	return c.raw
+}
+
+func (t constraintTerm) check(v Version) bool {
+	cmp := t.version.comparePrefix(v)
+	switch t.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp < 0
+	case ">=":
+		return cmp <= 0
+	case "<":
+		return cmp > 0
+	case "<=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// comparePrefix compares v with o on the components v specifies, so "1.2"
+// equals 1.2.7. It returns -1 when v is older than o.
+func (v Version) comparePrefix(o Version) int {
+	if v.parts == 0 || v.parts >= 3 {
+		return v.Compare(o)
+	}
+	if c := compareInts(v.Major, o.Major); c != 0 || v.parts == 1 {
+		return c
+	}
+	return compareInts(v.Minor, o.Minor)
+}
+
+// SatisfiesVersion reports whether version satisfies constraint. It fails
+// when either cannot be parsed.
+func SatisfiesVersion(version, constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	v, err := ParseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
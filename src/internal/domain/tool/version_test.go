@@ -0,0 +1,109 @@
+package tool
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		given    string
+		expected string
+	}{
+		{"21.0.2", "21.0.2"},
+		{"go1.24", "1.24.0"},
+		{"go version go1.23.4 darwin/arm64", "1.23.4"},
+		{"v3.100.0", "3.100.0"},
+		{"eas-cli/16.32.0 darwin-arm64 node-v24.11.1", "16.32.0"},
+		{"codex-cli 0.46.0", "0.46.0"},
+		{"1.12.0+mac", "1.12.0"},
+		{"3.6a", "3.6.0a"},
+		{"1.8.0_392", "1.8.0"},
+		{"2.0.76 (Claude Code)", "2.0.76"},
+		{"1.2.3.4", "1.2.3"},
+		{"1.0.0-rc.2", "1.0.0-rc.2"},
+		{"25.0.1beta3", "25.0.1-beta3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			v, err := ParseVersion(tt.given)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error = %v", tt.given, err)
+			}
+			if got := v.String(); got != tt.expected {
+				t.Errorf("ParseVersion(%q) = %s, expected %s", tt.given, got, tt.expected)
+			}
+		})
+	}
+
+	for _, given := range []string{"", "unknown", "v"} {
+		if _, err := ParseVersion(given); err == nil {
+			t.Errorf("ParseVersion(%q) should fail", given)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.10.0", "1.9.9", 1},
+		{"go1.24", "1.23.4", 1},
+		{"21.0.2", "21.0.10", -1},
+		{"3.6a", "3.6", 1},
+		{"3.6a", "3.6b", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"1.12.0+mac", "1.12.0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := ParseVersion(tt.a)
+			b, _ := ParseVersion(tt.b)
+			if got := a.Compare(b); got != tt.expected {
+				t.Errorf("Compare(%s, %s) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSatisfiesVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		expected   bool
+	}{
+		{"1.5.0", ">=1.2 <2", true},
+		{"2.0.1", ">=1.2 <2", false},
+		{"1.1.9", ">=1.2, <2", false},
+		{"1.2.7", "1.2", true},
+		{"1.3.0", "=1.2", false},
+		{"21.0.2", ">= 21", true},
+		{"go1.24", ">=1.23.4", true},
+		{"3.6a", ">3.6", false},
+		{"3.6a", ">3.6.0", true},
+		{"16.32.0", "!=16.32", false},
+		{"0.46.0", "<=0.46.0", true},
+		{"1.0.0-rc.1", ">=1.0.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.constraint, func(t *testing.T) {
+			got, err := SatisfiesVersion(tt.version, tt.constraint)
+			if err != nil {
+				t.Fatalf("SatisfiesVersion() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("SatisfiesVersion(%q, %q) = %v, expected %v", tt.version, tt.constraint, got, tt.expected)
+			}
+		})
+	}
+
+	for _, given := range []string{"", ">=", ">=abc", "~>1.2"} {
+		if _, err := ParseConstraint(given); err == nil {
+			t.Errorf("ParseConstraint(%q) should fail", given)
+		}
+	}
+}
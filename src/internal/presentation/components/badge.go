@@ -27,10 +27,13 @@ func BadgeLoading(spinnerFrame string) string {
 	return theme.SpinnerStyle.Render(spinnerFrame)
 }
 
-// ServiceBadge renders a service status badge
-func ServiceBadge(running bool) string {
-	if running {
-		return theme.ServiceRunning.Render(theme.IconServiceOn) + " " + theme.Success.Render("running")
+// ServiceBadge renders a service health badge: "healthy", "degraded" or "down"
+func ServiceBadge(health string) string {
+	switch health {
+	case "healthy":
+		return theme.ServiceRunning.Render(theme.IconServiceOn) + " " + theme.Success.Render(health)
+	case "degraded":
+		return theme.ServiceStopped.Render(theme.IconServiceOn) + " " + theme.Warning.Render(health)
 	}
-	return theme.ServiceStopped.Render(theme.IconServiceOff) + " " + theme.Warning.Render("stopped")
+	return theme.ServiceDown.Render(theme.IconServiceOff) + " " + theme.Danger.Render(health)
 }
@@ -2,6 +2,7 @@ package print
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/components"
@@ -41,6 +42,12 @@ func Warning(msg string) {
 	fmt.Printf("%s %s\n", theme.Warning.Render("Warning:"), msg)
 }
 
+// WarningStderr prints a warning message to stderr, keeping stdout clean for
+// output other programs parse (such as shell completion)
+func WarningStderr(msg string) {
+	fmt.Fprintf(os.Stderr, "%s %s\n", theme.Warning.Render("Warning:"), msg)
+}
+
 // Success prints a success message with checkmark
 func Success(msg string) {
 	fmt.Printf("%s %s\n", theme.Success.Render(theme.IconCheck), msg)
@@ -56,6 +63,11 @@ func Dim(msg string) {
 	fmt.Println(theme.Muted.Render(msg))
 }
 
+// DimStderr prints a dimmed/muted message to stderr
+func DimStderr(msg string) {
+	fmt.Fprintln(os.Stderr, theme.Muted.Render(msg))
+}
+
 // =============================================================================
 // Action Print Functions
 // =============================================================================
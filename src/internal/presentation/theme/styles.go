@@ -147,6 +147,10 @@ var (
 	// ServiceStopped is for stopped service indicator
 	ServiceStopped = lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorWarning))
+
+	// ServiceDown is for a service that does not answer
+	ServiceDown = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(ColorDanger))
 )
 
 // =============================================================================
@@ -1,11 +1,12 @@
 package setup
 
 import (
-	"os/exec"
+	"io"
 	"sort"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jterrazz/jterrazz-cli/src/internal/config"
+	"github.com/jterrazz/jterrazz-cli/src/internal/domain/tool"
 	"github.com/jterrazz/jterrazz-cli/src/internal/presentation/components"
 )
 
@@ -160,8 +161,8 @@ func HandleSelect(index int, item components.Item, runScript func(string)) tea.C
 	default:
 		// Check if script uses ExecArgs (needs full terminal control)
 		if script := config.GetScriptByName(name); script != nil && len(script.ExecArgs) > 0 {
-			c := exec.Command(script.ExecArgs[0], script.ExecArgs[1:]...)
-			return tea.ExecProcess(c, func(err error) tea.Msg {
+			c := &loggedExec{cmd: tool.Cmd{Name: script.ExecArgs[0], Args: script.ExecArgs[1:], Logged: true, Interactive: true}}
+			return tea.Exec(c, func(err error) tea.Msg {
 				return components.ActionDoneMsg{Message: "Completed " + name}
 			})
 		}
@@ -175,13 +176,29 @@ func HandleSelect(index int, item components.Item, runScript func(string)) tea.C
 	}
 }
 
+// loggedExec runs a script through the tool runner, so it lands in the run log,
+// with the terminal bubbletea releases to it
+type loggedExec struct {
+	cmd tool.Cmd
+}
+
+func (e *loggedExec) Run() error            { return tool.RunCmd(e.cmd) }
+func (e *loggedExec) SetStdin(r io.Reader)  { e.cmd.Stdin = r }
+func (e *loggedExec) SetStdout(w io.Writer) { e.cmd.Stdout = w }
+func (e *loggedExec) SetStderr(w io.Writer) { e.cmd.Stderr = w }
+
 // RunOrExit runs the setup TUI
 func RunOrExit(runScript func(string)) {
-	components.RunOrExit(components.AppConfig{
+	components.RunOrExit(SetupConfig(runScript))
+}
+
+// SetupConfig returns the setup TUI configuration
+func SetupConfig(runScript func(string)) components.AppConfig {
+	return components.AppConfig{
 		Title:      "Setup",
 		BuildItems: BuildItems,
 		OnSelect: func(index int, item components.Item) tea.Cmd {
 			return HandleSelect(index, item, runScript)
 		},
-	})
+	}
 }
@@ -38,9 +38,10 @@ type Model struct {
 	allLoaded bool
 }
 
-// New creates a new status view model
-func New() Model {
-	loader := status.NewLoader()
+// New creates a new status view model, showing the checks cached in cache
+// first. A non-nil profile limits the tools and setup scripts shown.
+func New(cache *config.CheckCache, profile *config.Profile) Model {
+	loader := status.NewLoader(cache, profile)
 	items := make(map[string]status.Item)
 	itemOrder := loader.GetItems()
 
@@ -121,7 +122,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 	case status.UpdateMsg:
+		wasLoaded := false
 		if existing, ok := m.items[msg.ID]; ok {
+			wasLoaded = existing.Loaded
 			existing.Loaded = msg.Item.Loaded
 			existing.Installed = msg.Item.Installed
 			existing.Version = msg.Item.Version
@@ -131,11 +134,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			existing.Style = msg.Item.Style
 			existing.Available = msg.Item.Available
 			existing.Processes = msg.Item.Processes
+			existing.Latest = msg.Item.Latest
+			existing.Required = msg.Item.Required
+			existing.Health = msg.Item.Health
+			existing.HealthReason = msg.Item.HealthReason
 			m.items[msg.ID] = existing
 		} else {
 			m.items[msg.ID] = msg.Item
 		}
-		if msg.Item.Loaded {
+		if msg.Item.Loaded && !wasLoaded { // Items may be sent again with more data
 			m.loaded++
 		}
 		cmds = append(cmds, m.loader.WaitForUpdate())
@@ -248,15 +255,15 @@ func (m Model) renderProgressBar() string {
 }
 
 // Run starts the status TUI
-func Run() error {
-	p := tea.NewProgram(New(), tea.WithAltScreen())
+func Run(cache *config.CheckCache, profile *config.Profile) error {
+	p := tea.NewProgram(New(cache, profile), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
 
 // RunOrExit runs the status TUI and exits on error
-func RunOrExit() {
-	if err := Run(); err != nil {
+func RunOrExit(cache *config.CheckCache, profile *config.Profile) {
+	if err := Run(cache, profile); err != nil {
 		fmt.Printf("Error: %v\n", err)
 	}
 }
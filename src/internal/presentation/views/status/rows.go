@@ -83,7 +83,7 @@ func (m Model) renderToolRow(item status.Item, colWidths ColumnWidths) string {
 	statusBadge := components.Badge(item.Installed)
 	version := components.CellSpecial(item.Version, colWidths.Version)
 
-	// Show service status for docker/ollama
+	// Show the status reported by the check
 	extra := ""
 	if item.Status != "" {
 		if item.Status == "running" {
@@ -96,6 +96,19 @@ func (m Model) renderToolRow(item status.Item, colWidths ColumnWidths) string {
 		}
 	}
 
+	if item.Health != "" {
+		extra += components.ColumnSeparator + components.ServiceBadge(item.Health)
+		if item.HealthReason != "" {
+			extra += " " + components.Muted(item.HealthReason)
+		}
+	}
+	if item.Required != "" {
+		extra += components.ColumnSeparator + components.Warning("too old, needs "+item.Required)
+	}
+	if item.Latest != "" {
+		extra += components.ColumnSeparator + components.Warning("outdated → "+item.Latest)
+	}
+
 	return components.RowPrefix + name + components.ColumnSeparator + method + components.ColumnSeparator + statusBadge + components.ColumnSeparator + version + extra
 }
 